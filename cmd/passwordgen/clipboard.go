@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// copyPassword копирует пароль в буфер обмена через clip и выводит
+// подтверждение в out вместо самого пароля
+func copyPassword(pwd string, clip func(string) error, out io.Writer) error {
+	if err := clip(pwd); err != nil {
+		return fmt.Errorf("не удалось скопировать пароль в буфер обмена: %w", err)
+	}
+
+	fmt.Fprintln(out, "Пароль скопирован в буфер обмена")
+	return nil
+}
+
+// systemClipboard записывает текст в системный буфер обмена с помощью
+// стандартной утилиты платформы
+func systemClipboard(text string) error {
+	cmd, err := clipboardCommand()
+	if err != nil {
+		return err
+	}
+
+	cmd.Stdin = strings.NewReader(text)
+	return cmd.Run()
+}
+
+// clipboardCommand подбирает команду копирования для текущей ОС
+func clipboardCommand() (*exec.Cmd, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("pbcopy"), nil
+	case "windows":
+		return exec.Command("clip"), nil
+	}
+
+	// Linux и прочие: пробуем Wayland, затем X11
+	candidates := [][]string{
+		{"wl-copy"},
+		{"xclip", "-selection", "clipboard"},
+		{"xsel", "--clipboard", "--input"},
+	}
+	for _, args := range candidates {
+		if path, err := exec.LookPath(args[0]); err == nil {
+			return exec.Command(path, args[1:]...), nil
+		}
+	}
+
+	return nil, fmt.Errorf("не найдена утилита для работы с буфером обмена (wl-copy, xclip или xsel)")
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCopyPassword(t *testing.T) {
+	var copied string
+	clip := func(text string) error {
+		copied = text
+		return nil
+	}
+
+	var out bytes.Buffer
+	if err := copyPassword("s3cr3t", clip, &out); err != nil {
+		t.Fatalf("copyPassword() failed: %v", err)
+	}
+
+	if copied != "s3cr3t" {
+		t.Errorf("clipboard got %q, want %q", copied, "s3cr3t")
+	}
+	if strings.Contains(out.String(), "s3cr3t") {
+		t.Errorf("output %q must not contain the password", out.String())
+	}
+	if out.Len() == 0 {
+		t.Error("Expected confirmation message, got empty output")
+	}
+}
+
+func TestCopyPasswordError(t *testing.T) {
+	clip := func(string) error {
+		return errors.New("нет буфера обмена")
+	}
+
+	var out bytes.Buffer
+	if err := copyPassword("s3cr3t", clip, &out); err == nil {
+		t.Error("Expected error from failing clipboard, got none")
+	}
+	if out.Len() != 0 {
+		t.Errorf("output = %q, want empty on failure", out.String())
+	}
+}
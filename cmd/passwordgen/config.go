@@ -0,0 +1,225 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/big"
+
+	"github.com/vikto/passwordgen/internal/password"
+)
+
+// enableAllSets включает все встроенные наборы символов
+func enableAllSets(config *password.Config) {
+	config.UseDigits = true
+	config.UseLower = true
+	config.UseUpper = true
+	config.UseSymbols = true
+}
+
+// applyPIN настраивает конфигурацию на PIN-код: только цифры с повторами
+func applyPIN(config *password.Config) {
+	config.UseDigits = true
+	config.UseLower = false
+	config.UseUpper = false
+	config.UseSymbols = false
+	config.UseEmoji = false
+	config.CustomChars = ""
+	config.AllowRepeats = true
+}
+
+// WPA2/WPA3 допускают парольную фразу из 8-63 печатных символов ASCII
+const (
+	wifiMinLength     = 8
+	wifiMaxLength     = 63
+	wifiDefaultLength = 20
+)
+
+// wifiSymbols - спецсимволы, которые легко ввести на телефоне и телевизоре
+// и которые не путаются между собой
+const wifiSymbols = "!#%+-=?@_"
+
+// wifiAmbiguous - похожие друг на друга символы, исключаемые для Wi-Fi
+const wifiAmbiguous = "0O1lI"
+
+// applyWiFi настраивает конфигурацию на пароль Wi-Fi: буквы, цифры и
+// безопасные спецсимволы без похожих символов
+func applyWiFi(config *password.Config) {
+	config.UseDigits = true
+	config.UseLower = true
+	config.UseUpper = true
+	config.UseSymbols = false
+	config.UseEmoji = false
+	config.CustomChars = wifiSymbols
+	config.ExcludeChars += wifiAmbiguous
+}
+
+// wifiLength задаёт длину пароля Wi-Fi по умолчанию и проверяет, что
+// длина укладывается в допустимый для WPA диапазон
+func wifiLength(config *password.Config) error {
+	if config.Length == 0 && config.MinLength == 0 && config.MaxLength == 0 {
+		config.Length = wifiDefaultLength
+	}
+
+	for _, length := range []int{config.Length, config.MinLength, config.MaxLength} {
+		if length != 0 && (length < wifiMinLength || length > wifiMaxLength) {
+			return fmt.Errorf("длина пароля Wi-Fi должна быть от %d до %d символов, указано %d", wifiMinLength, wifiMaxLength, length)
+		}
+	}
+	return nil
+}
+
+// mobileAmbiguous - символы, которые на экране телефона легко спутать
+// при переписывании пароля
+const mobileAmbiguous = "0o1l"
+
+// mobileLetterWeight - во сколько раз буквы выбираются чаще цифр: для
+// ввода цифр на мобильной клавиатуре приходится переключать раскладку
+const mobileLetterWeight = 3
+
+// applyMobile настраивает конфигурацию на пароль, удобный для ввода с
+// телефона: только маленькие буквы и реже цифры, без спецсимволов,
+// переключения регистра и похожих символов
+func applyMobile(config *password.Config) {
+	config.UseDigits = true
+	config.UseLower = true
+	config.UseUpper = false
+	config.UseSymbols = false
+	config.UseEmoji = false
+	config.CustomChars = ""
+	config.ExcludeChars += mobileAmbiguous
+	config.Weights = map[string]int{"lower": mobileLetterWeight, "digits": 1}
+}
+
+// passphraseSeparator соединяет слова парольной фразы -wordlist
+const passphraseSeparator = "-"
+
+// Код ваучера по умолчанию - 12 символов в группах по 4: "ABCD-EFGH-JKMN"
+const (
+	voucherDefaultLength = 12
+	voucherGroup         = 4
+)
+
+// voucherAmbiguous - буквы и цифры, которые путают при вводе кода с
+// бумаги или экрана, в обоих регистрах
+const voucherAmbiguous = "0O1Iol"
+
+// applyVoucher настраивает конфигурацию на код ваучера: большие (или при
+// lowercase маленькие) буквы и цифры без похожих символов. Повторы
+// разрешены - код читается по группам, а не по символам.
+func applyVoucher(config *password.Config, lowercase bool) {
+	config.UseDigits = true
+	config.UseLower = lowercase
+	config.UseUpper = !lowercase
+	config.UseSymbols = false
+	config.UseEmoji = false
+	config.CustomChars = ""
+	config.ExcludeChars += voucherAmbiguous
+	config.AllowRepeats = true
+	if config.Length == 0 && config.MinLength == 0 && config.MaxLength == 0 {
+		config.Length = voucherDefaultLength
+	}
+}
+
+// setFlags возвращает имена флагов, явно указанных в командной строке.
+// Флаги -all, -pin, -wifi, -mobile-friendly и -voucher неявно задают наборы
+// символов.
+func setFlags(all, pin, wifi, mobile, voucher bool) map[string]bool {
+	set := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+
+	if all {
+		for _, name := range []string{"digits", "lower", "upper", "symbols"} {
+			set[name] = true
+		}
+	}
+
+	if pin {
+		for _, name := range []string{"digits", "lower", "upper", "symbols", "emoji", "custom", "repeats"} {
+			set[name] = true
+		}
+	}
+
+	if wifi || mobile {
+		for _, name := range []string{"digits", "lower", "upper", "symbols", "emoji", "custom", "exclude"} {
+			set[name] = true
+		}
+	}
+
+	if mobile {
+		set["weights"] = true
+	}
+
+	if voucher {
+		for _, name := range []string{"digits", "lower", "upper", "symbols", "emoji", "custom", "exclude", "repeats"} {
+			set[name] = true
+		}
+	}
+	return set
+}
+
+// mergeConfig накладывает значения из flags на base для явно указанных флагов
+func mergeConfig(base, flags password.Config, set map[string]bool) password.Config {
+	merged := base
+
+	if set["length"] || set["l"] {
+		merged.Length = flags.Length
+	}
+	if set["min-length"] {
+		merged.MinLength = flags.MinLength
+	}
+	if set["max-length"] {
+		merged.MaxLength = flags.MaxLength
+	}
+	if set["digits"] {
+		merged.UseDigits = flags.UseDigits
+	}
+	if set["lower"] {
+		merged.UseLower = flags.UseLower
+	}
+	if set["upper"] {
+		merged.UseUpper = flags.UseUpper
+	}
+	if set["symbols"] {
+		merged.UseSymbols = flags.UseSymbols
+	}
+	if set["emoji"] {
+		merged.UseEmoji = flags.UseEmoji
+	}
+	if set["custom"] {
+		merged.CustomChars = flags.CustomChars
+	}
+	if set["exclude"] {
+		merged.ExcludeChars = flags.ExcludeChars
+	}
+	if set["weights"] {
+		merged.Weights = flags.Weights
+	}
+	if set["repeats"] {
+		merged.AllowRepeats = flags.AllowRepeats
+	}
+	if set["safe-symbols"] {
+		merged.UseSymbols = flags.UseSymbols
+		merged.SafeSymbolsOnly = flags.SafeSymbolsOnly
+	}
+	if set["min-classes"] {
+		merged.MinClasses = flags.MinClasses
+	}
+
+	return merged
+}
+
+// validateCount проверяет до генерации, что count положителен и не превышает
+// число возможных уникальных паролей для конфигурации генератора
+func validateCount(gen *password.Generator, count int) error {
+	if count <= 0 {
+		return fmt.Errorf("%w: требуется положительное число", password.ErrInvalidCount)
+	}
+
+	if maxUnique := gen.MaxUnique(); big.NewInt(int64(count)).Cmp(maxUnique) > 0 {
+		return fmt.Errorf("%w: для этой конфигурации возможно только %s уникальных паролей, а запрошено %d", password.ErrCharsetExhausted, maxUnique, count)
+	}
+
+	return nil
+}
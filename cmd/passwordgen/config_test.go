@@ -0,0 +1,252 @@
+package main
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/vikto/passwordgen/internal/password"
+)
+
+func TestMergeConfig(t *testing.T) {
+	base := password.Config{
+		Length:       20,
+		UseDigits:    true,
+		UseLower:     true,
+		UseSymbols:   true,
+		ExcludeChars: "0O",
+	}
+	flags := password.Config{
+		Length:     12,
+		UseDigits:  false,
+		UseUpper:   true,
+		MinClasses: 2,
+	}
+
+	// Без явно указанных флагов конфигурация файла не меняется
+	if got := mergeConfig(base, flags, map[string]bool{}); !reflect.DeepEqual(got, base) {
+		t.Errorf("mergeConfig() without flags = %+v, want %+v", got, base)
+	}
+
+	got := mergeConfig(base, flags, map[string]bool{"l": true, "digits": true, "upper": true, "min-classes": true})
+	want := password.Config{
+		Length:       12,
+		UseDigits:    false,
+		UseLower:     true,
+		UseUpper:     true,
+		UseSymbols:   true,
+		ExcludeChars: "0O",
+		MinClasses:   2,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeConfig() = %+v, want %+v", got, want)
+	}
+}
+
+func TestEnableAllSets(t *testing.T) {
+	config := password.Config{Length: 20, ExcludeChars: "lI0O"}
+	enableAllSets(&config)
+
+	want := password.Config{
+		Length:       20,
+		UseDigits:    true,
+		UseLower:     true,
+		UseUpper:     true,
+		UseSymbols:   true,
+		ExcludeChars: "lI0O",
+	}
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("enableAllSets() = %+v, want %+v", config, want)
+	}
+
+	if _, err := password.NewGenerator(config); err != nil {
+		t.Errorf("NewGenerator() failed for -all with -exclude: %v", err)
+	}
+
+	// Исключение всех символов даёт пустой набор и ошибку
+	config.ExcludeChars = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ!@#$%^&*()-_=+[]{}<>?,.;:"
+	if _, err := password.NewGenerator(config); err == nil {
+		t.Error("Expected error when -exclude empties the charset, got none")
+	}
+}
+
+func TestApplyPIN(t *testing.T) {
+	config := password.Config{Length: 6, UseLower: true, UseSymbols: true, UseEmoji: true, CustomChars: "ж"}
+	applyPIN(&config)
+
+	want := password.Config{Length: 6, UseDigits: true, AllowRepeats: true}
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("applyPIN() = %+v, want %+v", config, want)
+	}
+}
+
+func TestValidateCount(t *testing.T) {
+	// 3 цифры без повторов: 10*9*8 = 720 паролей
+	gen, err := password.NewGenerator(password.Config{Length: 3, UseDigits: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	tests := []struct {
+		count   int
+		wantErr bool
+	}{
+		{count: 1, wantErr: false},
+		{count: 720, wantErr: false},
+		{count: 721, wantErr: true},
+		{count: 100000, wantErr: true},
+		{count: 0, wantErr: true},
+		{count: -5, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		err := validateCount(gen, tt.count)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("validateCount(%d) error = %v, wantErr %v", tt.count, err, tt.wantErr)
+		}
+	}
+
+	if err := validateCount(gen, 100000); err == nil || !strings.Contains(err.Error(), "только 720 уникальных паролей") {
+		t.Errorf("validateCount() error = %v, want mention of 720 passwords", err)
+	}
+}
+
+func TestApplyWiFi(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  password.Config
+		wantErr bool
+	}{
+		{name: "длина по умолчанию", config: password.Config{}},
+		{name: "минимальная длина", config: password.Config{Length: 8}},
+		{name: "максимальная длина", config: password.Config{Length: 63}},
+		{name: "диапазон длин", config: password.Config{MinLength: 12, MaxLength: 40}},
+		{name: "эмодзи и спецсимволы отключаются", config: password.Config{Length: 16, UseSymbols: true, UseEmoji: true}},
+		{name: "слишком короткий", config: password.Config{Length: 7}, wantErr: true},
+		{name: "слишком длинный", config: password.Config{Length: 64}, wantErr: true},
+		{name: "диапазон за пределами WPA", config: password.Config{MinLength: 8, MaxLength: 80}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			applyWiFi(&config)
+			err := wifiLength(&config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("wifiLength() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			gen, err := password.NewGenerator(config)
+			if err != nil {
+				t.Fatalf("NewGenerator() failed: %v", err)
+			}
+			passwords, err := gen.GenerateUnique(50)
+			if err != nil {
+				t.Fatalf("GenerateUnique() failed: %v", err)
+			}
+
+			for _, pwd := range passwords {
+				if len(pwd) < wifiMinLength || len(pwd) > wifiMaxLength {
+					t.Errorf("Password %q has length %d outside WPA range", pwd, len(pwd))
+				}
+				for _, r := range pwd {
+					if r < '!' || r > '~' {
+						t.Errorf("Password %q contains non-printable or non-ASCII %q", pwd, r)
+					}
+					if strings.ContainsRune(wifiAmbiguous, r) {
+						t.Errorf("Password %q contains ambiguous %q", pwd, r)
+					}
+					isAlnum := (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+					if !isAlnum && !strings.ContainsRune(wifiSymbols, r) {
+						t.Errorf("Password %q contains unsafe symbol %q", pwd, r)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestApplyMobile(t *testing.T) {
+	config := password.Config{Length: 14, UseUpper: true, UseSymbols: true, UseEmoji: true, CustomChars: "äö"}
+	applyMobile(&config)
+
+	gen, err := password.NewGenerator(config)
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	want := "23456789abcdefghijkmnpqrstuvwxyz"
+	if got := gen.Charset(); got != want {
+		t.Errorf("Charset() = %q, want %q", got, want)
+	}
+
+	// Буквы выбираются чаще цифр
+	passwords, err := gen.GenerateUnique(200)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+	letters, digits := 0, 0
+	for _, pwd := range passwords {
+		for _, r := range pwd {
+			if r >= '0' && r <= '9' {
+				digits++
+			} else {
+				letters++
+			}
+		}
+	}
+	if letters <= digits*2 {
+		t.Errorf("letters = %d, digits = %d, want letters to dominate", letters, digits)
+	}
+}
+
+func TestApplyVoucher(t *testing.T) {
+	tests := []struct {
+		name      string
+		lowercase bool
+		charset   string
+		pattern   string
+	}{
+		{"большие буквы", false, "23456789ABCDEFGHJKLMNPQRSTUVWXYZ", `^[2-9A-HJ-NP-Z]{4}-[2-9A-HJ-NP-Z]{4}-[2-9A-HJ-NP-Z]{4}$`},
+		{"маленькие буквы", true, "23456789abcdefghijkmnpqrstuvwxyz", `^[2-9a-km-np-z]{4}-[2-9a-km-np-z]{4}-[2-9a-km-np-z]{4}$`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := password.Config{UseSymbols: true, UseEmoji: true, CustomChars: "äö"}
+			applyVoucher(&config, tt.lowercase)
+
+			gen, err := password.NewGenerator(config)
+			if err != nil {
+				t.Fatalf("NewGenerator() failed: %v", err)
+			}
+			if got := gen.Charset(); got != tt.charset {
+				t.Errorf("Charset() = %q, want %q", got, tt.charset)
+			}
+
+			style := lineFormat{group: voucherGroup, groupSep: "-"}
+			re := regexp.MustCompile(tt.pattern)
+			passwords, err := gen.GenerateUnique(50)
+			if err != nil {
+				t.Fatalf("GenerateUnique() failed: %v", err)
+			}
+			for _, pwd := range passwords {
+				if line := formatLine(pwd, style, nil); !re.MatchString(line) {
+					t.Errorf("code %q does not match %s", line, tt.pattern)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyVoucherKeepsLength(t *testing.T) {
+	config := password.Config{Length: 16}
+	applyVoucher(&config, false)
+	if config.Length != 16 {
+		t.Errorf("Length = %d, want 16", config.Length)
+	}
+}
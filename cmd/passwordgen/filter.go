@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// filterPredicates - условия флага -filter для систем с нестандартными
+// правилами. Пароль, не прошедший условие, перегенерируется.
+var filterPredicates = map[string]func(string) bool{
+	// Первый символ - буква
+	"starts-alpha": func(pwd string) bool {
+		first, _ := utf8.DecodeRuneInString(pwd)
+		return unicode.IsLetter(first)
+	},
+	// Последний символ - буква
+	"ends-alpha": func(pwd string) bool {
+		last, _ := utf8.DecodeLastRuneInString(pwd)
+		return unicode.IsLetter(last)
+	},
+	// Первый символ - буква или цифра
+	"no-symbol-first": func(pwd string) bool {
+		first, _ := utf8.DecodeRuneInString(pwd)
+		return isAlnum(first)
+	},
+	// Последний символ - буква или цифра
+	"no-symbol-last": func(pwd string) bool {
+		last, _ := utf8.DecodeLastRuneInString(pwd)
+		return isAlnum(last)
+	},
+}
+
+// isAlnum сообщает, является ли r буквой или цифрой
+func isAlnum(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// parseFilter возвращает условие -filter по имени
+func parseFilter(name string) (func(string) bool, error) {
+	predicate, ok := filterPredicates[name]
+	if !ok {
+		names := make([]string, 0, len(filterPredicates))
+		for known := range filterPredicates {
+			names = append(names, known)
+		}
+		slices.Sort(names)
+		return nil, fmt.Errorf("неизвестное условие -filter %q, допустимы: %s", name, strings.Join(names, ", "))
+	}
+	return predicate, nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vikto/passwordgen/internal/password"
+)
+
+func TestFilterPredicates(t *testing.T) {
+	tests := []struct {
+		name string
+		pwd  string
+		want bool
+	}{
+		{name: "starts-alpha", pwd: "a1!", want: true},
+		{name: "starts-alpha", pwd: "Ж1!", want: true},
+		{name: "starts-alpha", pwd: "1ab", want: false},
+		{name: "starts-alpha", pwd: "!ab", want: false},
+		{name: "ends-alpha", pwd: "1!b", want: true},
+		{name: "ends-alpha", pwd: "ab1", want: false},
+		{name: "ends-alpha", pwd: "ab#", want: false},
+		{name: "no-symbol-first", pwd: "a!#", want: true},
+		{name: "no-symbol-first", pwd: "7!#", want: true},
+		{name: "no-symbol-first", pwd: "#ab", want: false},
+		{name: "no-symbol-first", pwd: "😀ab", want: false},
+		{name: "no-symbol-last", pwd: "!#a", want: true},
+		{name: "no-symbol-last", pwd: "!#7", want: true},
+		{name: "no-symbol-last", pwd: "ab$", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"/"+tt.pwd, func(t *testing.T) {
+			predicate, err := parseFilter(tt.name)
+			if err != nil {
+				t.Fatalf("parseFilter(%q) failed: %v", tt.name, err)
+			}
+			if got := predicate(tt.pwd); got != tt.want {
+				t.Errorf("%s(%q) = %v, want %v", tt.name, tt.pwd, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterPredicatesGenerate(t *testing.T) {
+	for name := range filterPredicates {
+		t.Run(name, func(t *testing.T) {
+			predicate, err := parseFilter(name)
+			if err != nil {
+				t.Fatalf("parseFilter(%q) failed: %v", name, err)
+			}
+
+			config := password.Config{Length: 6, UseDigits: true, UseLower: true, UseSymbols: true, Accept: predicate}
+			gen, err := password.NewGenerator(config)
+			if err != nil {
+				t.Fatalf("NewGenerator() failed: %v", err)
+			}
+			passwords, err := gen.GenerateUnique(100)
+			if err != nil {
+				t.Fatalf("GenerateUnique() failed: %v", err)
+			}
+			for _, pwd := range passwords {
+				if !predicate(pwd) {
+					t.Errorf("Password %q does not satisfy %s", pwd, name)
+				}
+			}
+		})
+	}
+}
+
+func TestParseFilterUnknown(t *testing.T) {
+	_, err := parseFilter("starts-with-cat")
+	if err == nil {
+		t.Fatal("Expected error for unknown predicate, got none")
+	}
+	if !strings.Contains(err.Error(), "starts-alpha") {
+		t.Errorf("error = %q, want list of known predicates", err)
+	}
+}
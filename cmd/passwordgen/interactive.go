@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/vikto/passwordgen/internal/password"
+)
+
+// defaultSets - наборы символов по умолчанию в интерактивном режиме
+const defaultSets = "dlu"
+
+// runInteractive запускает интерактивный режим: запрашивает длину и наборы
+// символов, генерирует пароль и предлагает сгенерировать ещё, скопировать,
+// сменить параметры или выйти. Завершается по команде q или концу ввода.
+func runInteractive(in io.Reader, out io.Writer, clip func(string) error) error {
+	scanner := bufio.NewScanner(in)
+
+	// readLine выводит приглашение и читает строку; ok=false в конце ввода
+	readLine := func(prompt string) (string, bool) {
+		fmt.Fprint(out, prompt)
+		if !scanner.Scan() {
+			return "", false
+		}
+		return strings.TrimSpace(scanner.Text()), true
+	}
+
+	for {
+		gen, ok := promptGenerator(readLine, out)
+		if !ok {
+			return scanner.Err()
+		}
+
+		pwd, err := gen.Generate()
+		if err != nil {
+			fmt.Fprintf(out, "Ошибка: %v\n", err)
+			continue
+		}
+		fmt.Fprintln(out, pwd)
+
+	actions:
+		for {
+			action, ok := readLine("[r] ещё раз, [c] скопировать, [n] новые параметры, [q] выход: ")
+			if !ok {
+				return scanner.Err()
+			}
+
+			switch strings.ToLower(action) {
+			case "r", "":
+				if pwd, err = gen.Generate(); err != nil {
+					fmt.Fprintf(out, "Ошибка: %v\n", err)
+					continue
+				}
+				fmt.Fprintln(out, pwd)
+			case "c":
+				if err := copyPassword(pwd, clip, out); err != nil {
+					fmt.Fprintf(out, "Ошибка: %v\n", err)
+				}
+			case "n":
+				break actions
+			case "q":
+				return nil
+			default:
+				fmt.Fprintf(out, "Неизвестная команда %q\n", action)
+			}
+		}
+	}
+}
+
+// promptGenerator запрашивает параметры, пока не получится корректный генератор
+func promptGenerator(readLine func(string) (string, bool), out io.Writer) (*password.Generator, bool) {
+	for {
+		lengthText, ok := readLine("Длина пароля: ")
+		if !ok {
+			return nil, false
+		}
+		length, err := strconv.Atoi(lengthText)
+		if err != nil || length <= 0 {
+			fmt.Fprintln(out, "Ошибка: длина должна быть положительным числом")
+			continue
+		}
+
+		sets, ok := readLine("Наборы символов (d - цифры, l - строчные, u - прописные, s - символы) [" + defaultSets + "]: ")
+		if !ok {
+			return nil, false
+		}
+		if sets == "" {
+			sets = defaultSets
+		}
+
+		config := password.Config{Length: length}
+		if err := applySets(&config, sets); err != nil {
+			fmt.Fprintf(out, "Ошибка: %v\n", err)
+			continue
+		}
+
+		gen, err := password.NewGenerator(config)
+		if err != nil {
+			fmt.Fprintf(out, "Ошибка: %v\n", err)
+			continue
+		}
+		return gen, true
+	}
+}
+
+// applySets включает наборы символов по буквам: d - цифры, l - строчные,
+// u - прописные, s - спецсимволы
+func applySets(config *password.Config, sets string) error {
+	for _, set := range sets {
+		switch set {
+		case 'd':
+			config.UseDigits = true
+		case 'l':
+			config.UseLower = true
+		case 'u':
+			config.UseUpper = true
+		case 's':
+			config.UseSymbols = true
+		default:
+			return fmt.Errorf("неизвестный набор символов %q", string(set))
+		}
+	}
+	return nil
+}
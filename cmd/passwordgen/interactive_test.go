@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/vikto/passwordgen/internal/password"
+)
+
+func TestRunInteractive(t *testing.T) {
+	var copied []string
+	clip := func(text string) error {
+		copied = append(copied, text)
+		return nil
+	}
+
+	// Сценарий: длина 8 по умолчанию, ещё раз, копировать, новые параметры
+	// (сначала ошибочная длина), затем выход
+	input := strings.Join([]string{
+		"8", "", "r", "c", "n",
+		"abc", "4", "d", "q",
+	}, "\n")
+
+	var out bytes.Buffer
+	if err := runInteractive(strings.NewReader(input), &out, clip); err != nil {
+		t.Fatalf("runInteractive() failed: %v", err)
+	}
+
+	text := out.String()
+	if got := strings.Count(text, "Длина пароля: "); got != 3 {
+		t.Errorf("length prompted %d times, want 3", got)
+	}
+	if !strings.Contains(text, "длина должна быть положительным числом") {
+		t.Error("Expected invalid length error in output")
+	}
+	if len(copied) != 1 || utf8.RuneCountInString(copied[0]) != 8 {
+		t.Errorf("copied = %q, want one 8-character password", copied)
+	}
+	if !strings.Contains(text, "Пароль скопирован в буфер обмена") {
+		t.Error("Expected copy confirmation in output")
+	}
+
+	// Сгенерированы два пароля длины 8 и один PIN длины 4
+	var eight, four int
+	for _, line := range strings.Split(text, "\n") {
+		// пароли выводятся отдельной строкой после приглашения
+		if i := strings.LastIndex(line, ": "); i >= 0 {
+			line = line[i+2:]
+		}
+		switch {
+		case len(line) == 8 && !strings.ContainsAny(line, " []"):
+			eight++
+		case len(line) == 4 && strings.Trim(line, "0123456789") == "":
+			four++
+		}
+	}
+	if eight != 2 || four != 1 {
+		t.Errorf("got %d passwords of length 8 and %d of length 4, want 2 and 1\n%s", eight, four, text)
+	}
+}
+
+func TestRunInteractiveEOF(t *testing.T) {
+	var out bytes.Buffer
+	if err := runInteractive(strings.NewReader("12\nx\n"), &out, nil); err != nil {
+		t.Fatalf("runInteractive() failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "неизвестный набор символов") {
+		t.Errorf("Expected unknown set error, got %q", out.String())
+	}
+}
+
+func TestApplySets(t *testing.T) {
+	var config password.Config
+	if err := applySets(&config, "dus"); err != nil {
+		t.Fatalf("applySets() failed: %v", err)
+	}
+	if !config.UseDigits || config.UseLower || !config.UseUpper || !config.UseSymbols {
+		t.Errorf("applySets(\"dus\") = %+v", config)
+	}
+
+	if err := applySets(&config, "dx"); err == nil {
+		t.Error("Expected error for unknown set, got none")
+	}
+}
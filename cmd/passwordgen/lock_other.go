@@ -0,0 +1,16 @@
+//go:build !unix
+
+package main
+
+import "os"
+
+// lockFile на системах без flock ничего не делает: остаётся только
+// атомарность одиночной записи в режиме O_APPEND
+func lockFile(file *os.File) error {
+	return nil
+}
+
+// unlockFile парная к lockFile заглушка
+func unlockFile(file *os.File) error {
+	return nil
+}
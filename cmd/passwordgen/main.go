@@ -1,89 +1,543 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"math"
 	"os"
+	"time"
 
 	"github.com/vikto/passwordgen/internal/password"
 )
 
 func main() {
+	// Подкоманда serve запускает HTTP-сервер вместо генерации в терминале
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		if err := runServer(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Определяем флаги
 	var (
-		length  int
-		lengthL int
-		digits  bool
-		lower   bool
-		upper   bool
-		count   int
+		length            int
+		lengthL           int
+		bits              float64
+		minLength         int
+		maxLength         int
+		digits            bool
+		lower             bool
+		upper             bool
+		symbols           bool
+		safeSymbols       bool
+		emoji             bool
+		all               bool
+		custom            string
+		exclude           string
+		repeats           bool
+		minClasses        int
+		pin               bool
+		wifi              bool
+		score             bool
+		analyze           bool
+		showEntropy       bool
+		group             int
+		groupSep          string
+		copyClip          bool
+		qr                bool
+		storePath         string
+		output            string
+		appendTo          string
+		wordlistPath      string
+		words             int
+		encode            string
+		format            string
+		csvMeta           bool
+		estimate          bool
+		timeout           time.Duration
+		configPath        string
+		interactive       bool
+		delimiter         string
+		noTrailingNewline bool
+		quiet             bool
+		verbose           bool
+		count             int
+		shuffleOutput     bool
+		mobile            bool
+		filter            string
+		card              int
+		jsonPretty        bool
+		stdinCount        bool
+		voucher           bool
 	)
 
 	flag.IntVar(&length, "length", 0, "Длина пароля (обязательный параметр)")
 	flag.IntVar(&lengthL, "l", 0, "Длина пароля (короткий вариант)")
+	flag.Float64Var(&bits, "bits", 0, "Подобрать минимальную длину для заданной энтропии в битах и -count паролей (с -length - проверить длину)")
+	flag.IntVar(&minLength, "min-length", 0, "Минимальная длина пароля (вместо -length)")
+	flag.IntVar(&maxLength, "max-length", 0, "Максимальная длина пароля (вместо -length)")
 	flag.BoolVar(&digits, "digits", false, "Использовать цифры 0-9")
 	flag.BoolVar(&lower, "lower", false, "Использовать маленькие буквы a-z")
 	flag.BoolVar(&upper, "upper", false, "Использовать большие буквы A-Z")
+	flag.BoolVar(&symbols, "symbols", false, "Использовать специальные символы")
+	flag.BoolVar(&safeSymbols, "safe-symbols", false, "Только спецсимволы -_.+=, безопасные для URL и командной оболочки (включает -symbols)")
+	flag.BoolVar(&emoji, "emoji", false, "Использовать эмодзи (каждый считается одним символом)")
+	flag.BoolVar(&all, "all", false, "Использовать все наборы символов (-digits -lower -upper -symbols)")
+	flag.StringVar(&custom, "custom", "", "Дополнительный набор символов (поддерживается Unicode)")
+	flag.StringVar(&exclude, "exclude", "", "Символы, которые нужно исключить")
+	flag.BoolVar(&repeats, "repeats", false, "Разрешить повторение символов в пароле")
+	flag.StringVar(&filter, "filter", "", "Перегенерировать, пока пароль не пройдёт условие: starts-alpha, ends-alpha, no-symbol-first, no-symbol-last")
+	flag.IntVar(&minClasses, "min-classes", 0, "Требовать символы хотя бы из N разных наборов вместо каждого")
+	flag.BoolVar(&pin, "pin", false, "Сгенерировать числовой PIN-код (только цифры, повторы разрешены)")
+	flag.BoolVar(&wifi, "wifi", false, "Пароль Wi-Fi (WPA): 8-63 символа, буквы, цифры и безопасные спецсимволы без похожих символов")
+	flag.BoolVar(&mobile, "mobile-friendly", false, "Пароль для ввода с телефона: маленькие буквы и цифры без спецсимволов и похожих символов")
+	flag.BoolVar(&voucher, "voucher", false, "Код ваучера: большие буквы и цифры без похожих символов в группах по 4 (с -lower - маленькие буквы)")
+	flag.BoolVar(&score, "score", false, "Показать оценку надёжности каждого пароля (0-4)")
+	flag.BoolVar(&showEntropy, "show-entropy", false, "Показать энтропию каждого пароля в битах")
+	flag.BoolVar(&analyze, "analyze", false, "Показать состав каждого пароля по классам символов")
+	flag.IntVar(&group, "group", 0, "Разбить пароль на группы по N символов")
+	flag.StringVar(&groupSep, "group-sep", "-", "Разделитель групп для -group")
+	flag.BoolVar(&copyClip, "copy", false, "Скопировать пароль в буфер обмена вместо вывода (только для -count 1)")
+	flag.BoolVar(&qr, "qr", false, "Вывести пароль в виде QR-кода (только для -count 1)")
+	flag.StringVar(&storePath, "store", "", "Файл с ранее выданными паролями для уникальности между запусками")
+	flag.StringVar(&output, "output", "", "Записать пароли в новый файл (права 0600) вместо вывода")
+	flag.StringVar(&appendTo, "append-to-file", "", "Дописать пароли в конец файла с блокировкой (права 0600 для нового файла)")
+	flag.StringVar(&wordlistPath, "wordlist", "", "Файл словаря (слово на строку, # - комментарий): выводить парольные фразы вместо паролей")
+	flag.IntVar(&words, "words", 6, "Количество слов в парольной фразе для -wordlist")
+	flag.StringVar(&encode, "encode", "", "Случайные байты длиной -length в кодировке hex или base64 вместо пароля")
+	flag.StringVar(&format, "format", "text", "Формат вывода: text, csv или json")
+	flag.BoolVar(&jsonPretty, "json-pretty", false, "JSON с отступами: конфигурация и пароли (включает -format json)")
+	flag.BoolVar(&csvMeta, "csv-meta", false, "Добавить в CSV колонки index, length и entropy")
+	flag.DurationVar(&timeout, "timeout", 0, "Ограничение времени генерации, например 5s (0 - без ограничения)")
+	flag.BoolVar(&estimate, "estimate", false, "Только оценить выполнимость генерации без создания паролей")
+	flag.StringVar(&configPath, "config", "", "JSON-файл с конфигурацией (явно указанные флаги имеют приоритет)")
+	flag.BoolVar(&interactive, "interactive", false, "Интерактивный режим: параметры запрашиваются в терминале")
+	flag.StringVar(&delimiter, "delimiter", "\n", "Разделитель между паролями при выводе")
+	flag.BoolVar(&noTrailingNewline, "no-trailing-newline", false, "Не выводить перевод строки в конце")
+	flag.BoolVar(&quiet, "quiet", false, "Выводить только пароли и ошибки")
+	flag.BoolVar(&verbose, "verbose", false, "Выводить в stderr конфигурацию, энтропию и статистику попыток")
 	flag.IntVar(&count, "count", 1, "Количество паролей для генерации")
+	flag.IntVar(&card, "card", 0, "Вывести N пронумерованных вариантов для выбора (вместо -count)")
+	flag.BoolVar(&stdinCount, "stdin-count", false, "Читать из stdin по числу на строке и выводить столько паролей, группы разделяются пустой строкой")
+	flag.BoolVar(&shuffleOutput, "shuffle-output", false, "Перемешать порядок паролей в выводе")
 
 	// Кастомизируем help
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Генератор уникальных паролей\n\n")
 		fmt.Fprintf(os.Stderr, "Использование:\n")
-		fmt.Fprintf(os.Stderr, "  %s [опции]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s [опции]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s serve [-addr localhost:8080]\n\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "Примеры:\n")
 		fmt.Fprintf(os.Stderr, "  %s -length 12 -digits -lower -upper\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -l 10 -digits -lower -count 5\n", os.Args[0])
-		fmt.Fprintf(os.Stderr, "  %s -length 8 -upper -count 3\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -length 8 -upper -count 3\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -length 20 -all -exclude \"lI0O\"\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -length 4 -pin\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -wifi\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -length 14 -mobile-friendly\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -voucher -count 10\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -wordlist words.txt -words 5\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -min-length 12 -max-length 20 -lower -upper -digits\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -length 16 -lower -upper -symbols -exclude \"lI0O\"\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -length 32 -encode hex\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -length 12 -all -card 10\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -length 16 -all -count 100 -format csv -csv-meta\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -length 16 -all -count 5 -json-pretty\n\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "Опции:\n")
 		flag.PrintDefaults()
 	}
 
 	flag.Parse()
 
+	level, err := parseVerbosity(quiet, verbose)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+		os.Exit(1)
+	}
+	rep := reporter{level: level, w: os.Stderr}
+
+	// Интерактивный режим сам запрашивает все параметры
+	if interactive {
+		if err := runInteractive(os.Stdin, os.Stdout, systemClipboard); err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Выбираем длину (приоритет у -length, если оба не указаны - ошибка)
 	finalLength := length
 	if finalLength == 0 {
 		finalLength = lengthL
 	}
 
-	if finalLength <= 0 {
-		fmt.Fprintf(os.Stderr, "Ошибка: необходимо указать длину пароля через -length или -l\n\n")
-		flag.Usage()
+	// Создаём конфигурацию
+	config := password.Config{
+		Length:       finalLength,
+		MinLength:    minLength,
+		MaxLength:    maxLength,
+		UseDigits:    digits,
+		UseLower:     lower,
+		UseUpper:     upper,
+		UseSymbols:   symbols || safeSymbols,
+		UseEmoji:     emoji,
+		CustomChars:  custom,
+		ExcludeChars: exclude,
+		AllowRepeats: repeats,
+		MinClasses:   minClasses,
+
+		SafeSymbolsOnly: safeSymbols,
+	}
+
+	// -all включает сразу все наборы символов
+	if all {
+		enableAllSets(&config)
+	}
+
+	presets := 0
+	for _, preset := range []bool{pin, wifi, mobile, voucher} {
+		if preset {
+			presets++
+		}
+	}
+	if presets > 1 {
+		fmt.Fprintf(os.Stderr, "Ошибка: -pin, -wifi, -mobile-friendly и -voucher нельзя использовать вместе\n")
+		os.Exit(1)
+	}
+
+	// PIN-код - это только цифры с разрешёнными повторами
+	if pin {
+		applyPIN(&config)
+	}
+
+	// Пароль Wi-Fi - буквы, цифры и безопасные спецсимволы
+	if wifi {
+		applyWiFi(&config)
+	}
+
+	// Пароль для телефона - маленькие буквы и реже цифры
+	if mobile {
+		applyMobile(&config)
+	}
+
+	// Код ваучера - буквы одного регистра и цифры, по группам
+	if voucher {
+		applyVoucher(&config, lower)
+		if group == 0 {
+			group = voucherGroup
+		}
+	}
+
+	// Загружаем конфигурацию из файла, явно указанные флаги имеют приоритет
+	if configPath != "" {
+		fileConfig, err := password.LoadConfig(configPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+			os.Exit(1)
+		}
+		config = mergeConfig(fileConfig, config, setFlags(all, pin, wifi, mobile, voucher))
+	}
+
+	// Длина по требуемой энтропии и количеству паролей: без -length
+	// подбирается, с -length - проверяется
+	if bits > 0 {
+		planned := count
+		if card > 0 {
+			planned = card
+		}
+		config, err = password.ConfigForEntropy(config, bits, planned)
+		if err != nil {
+			printError(os.Stderr, "Ошибка", err)
+			os.Exit(1)
+		}
+	}
+
+	if wifi {
+		if err := wifiLength(&config); err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Длина и наборы символов не нужны для парольных фраз
+	if wordlistPath == "" && config.Length <= 0 && config.MinLength <= 0 && config.MaxLength <= 0 {
+		fmt.Fprintf(os.Stderr, "Ошибка: необходимо указать длину пароля через -length, -l или -min-length и -max-length\n\n")
+		rep.usage()
 		os.Exit(1)
 	}
 
-	// Проверяем, что выбран хотя бы один набор символов
-	if !digits && !lower && !upper {
-		fmt.Fprintf(os.Stderr, "Ошибка: необходимо выбрать хотя бы один набор символов (-digits, -lower или -upper)\n\n")
-		flag.Usage()
+	// Проверяем, что выбран хотя бы один набор символов (кроме режимов -encode и -wordlist)
+	if encode == "" && wordlistPath == "" && !config.UseDigits && !config.UseLower && !config.UseUpper && !config.UseSymbols && !config.UseEmoji && config.CustomChars == "" && len(config.CustomGroups) == 0 {
+		fmt.Fprintf(os.Stderr, "Ошибка: необходимо выбрать хотя бы один набор символов (-digits, -lower, -upper, -symbols, -emoji, -custom или -all)\n\n")
+		rep.usage()
 		os.Exit(1)
 	}
 
-	// Создаём конфигурацию
-	config := password.Config{
-		Length:    finalLength,
-		UseDigits: digits,
-		UseLower:  lower,
-		UseUpper:  upper,
+	// -json-pretty - это JSON с отступами
+	if jsonPretty {
+		if format != "text" && format != "json" {
+			fmt.Fprintf(os.Stderr, "Ошибка: -json-pretty нельзя использовать вместе с -format %s\n", format)
+			os.Exit(1)
+		}
+		format = "json"
 	}
 
-	// Создаём генератор
-	gen, err := password.NewGenerator(config)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Ошибка создания генератора: %v\n", err)
+	// Карточка вариантов - это count паролей с нумерацией
+	if card < 0 {
+		fmt.Fprintf(os.Stderr, "Ошибка: -card должно быть положительным числом\n")
 		os.Exit(1)
 	}
+	if card > 0 {
+		if count != 1 {
+			fmt.Fprintf(os.Stderr, "Ошибка: -card и -count нельзя использовать вместе\n")
+			os.Exit(1)
+		}
+		if copyClip || qr || format != "text" {
+			fmt.Fprintf(os.Stderr, "Ошибка: -card нельзя использовать вместе с -copy, -qr и -format csv или json\n")
+			os.Exit(1)
+		}
+		count = card
+	}
 
-	// Генерируем пароли
-	passwords, err := gen.GenerateUnique(count)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Ошибка генерации паролей: %v\n", err)
+	if stdinCount && (count != 1 || card > 0 || encode != "" || copyClip || qr || output != "" || format != "text") {
+		fmt.Fprintf(os.Stderr, "Ошибка: -stdin-count нельзя использовать вместе с -count, -card, -encode, -copy, -qr, -output и -format\n")
 		os.Exit(1)
 	}
 
-	// Выводим результат
-	for _, pwd := range passwords {
-		fmt.Println(pwd)
+	if copyClip && count != 1 {
+		fmt.Fprintf(os.Stderr, "Ошибка: -copy можно использовать только с -count 1\n")
+		os.Exit(1)
 	}
+
+	if qr && count != 1 {
+		fmt.Fprintf(os.Stderr, "Ошибка: -qr можно использовать только с -count 1\n")
+		os.Exit(1)
+	}
+
+	if format != "text" && format != "csv" && format != "json" {
+		fmt.Fprintf(os.Stderr, "Ошибка: неизвестный формат %q, допустимы text, csv и json\n", format)
+		os.Exit(1)
+	}
+
+	if format != "text" && (copyClip || qr) {
+		fmt.Fprintf(os.Stderr, "Ошибка: -format %s нельзя использовать вместе с -copy и -qr\n", format)
+		os.Exit(1)
+	}
+
+	if copyClip && output != "" {
+		fmt.Fprintf(os.Stderr, "Ошибка: -copy и -output нельзя использовать вместе\n")
+		os.Exit(1)
+	}
+
+	if wordlistPath != "" && (encode != "" || stdinCount || estimate) {
+		fmt.Fprintf(os.Stderr, "Ошибка: -wordlist нельзя использовать вместе с -encode, -stdin-count и -estimate\n")
+		os.Exit(1)
+	}
+
+	if appendTo != "" && (output != "" || copyClip || qr || stdinCount || format != "text") {
+		fmt.Fprintf(os.Stderr, "Ошибка: -append-to-file нельзя использовать вместе с -output, -copy, -qr, -stdin-count и -format\n")
+		os.Exit(1)
+	}
+
+	// Дополнительное условие для нестандартных правил
+	if filter != "" {
+		predicate, err := parseFilter(filter)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+			os.Exit(1)
+		}
+		config.Accept = predicate
+	}
+
+	// Подключаем хранилище использованных паролей
+	if storePath != "" {
+		store, err := password.NewFileStore(storePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+			os.Exit(1)
+		}
+		defer store.Close()
+		config.Store = store
+	}
+
+	style := lineFormat{group: group, groupSep: groupSep, showEntropy: showEntropy, score: score, analyze: analyze}
+
+	var passwords []string
+	var entropyOf func(string) float64
+	if wordlistPath != "" {
+		wordlist, err := password.LoadWordlist(wordlistPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+			os.Exit(1)
+		}
+		// Каждое слово выбирается независимо из всего словаря
+		entropyOf = func(string) float64 { return float64(words) * math.Log2(float64(len(wordlist))) }
+		passwords, err = password.GeneratePassphrases(wordlist, words, count, passphraseSeparator)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка генерации фраз: %v\n", err)
+			os.Exit(1)
+		}
+	} else if encode != "" {
+		// Энтропия токена определяется числом случайных байт
+		entropyOf = func(string) float64 { return float64(config.Length * 8) }
+		// Кодированные токены генерируются в обход наборов символов
+		for i := 0; i < count; i++ {
+			token, err := password.GenerateEncoded(config.Length, encode)
+			if err != nil {
+				printError(os.Stderr, "Ошибка генерации токена", err)
+				os.Exit(1)
+			}
+			passwords = append(passwords, token)
+		}
+	} else {
+		// Показываем прогресс больших пачек в режиме -verbose
+		if rep.level == verboseOutput && count >= progressMinCount {
+			config.Progress = rep.progress
+		}
+
+		// Создаём генератор
+		gen, err := password.NewGenerator(config)
+		if err != nil {
+			printError(os.Stderr, "Ошибка создания генератора", err)
+			os.Exit(1)
+		}
+		rep.reportConfig(config, gen.Entropy())
+
+		// Количество паролей читается из stdin построчно
+		if stdinCount {
+			format := func(pwd string) string { return formatLine(pwd, style, gen.PasswordEntropy) }
+			if err := runStdinCount(os.Stdin, os.Stdout, gen, format); err != nil {
+				printError(os.Stderr, "Ошибка", err)
+				os.Exit(1)
+			}
+			return
+		}
+
+		// Оцениваем выполнимость вместо генерации
+		if estimate {
+			result, err := gen.Estimate(count)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Ошибка оценки: %v\n", err)
+				os.Exit(1)
+			}
+			printEstimate(os.Stdout, result)
+			return
+		}
+
+		// Проверяем количество до начала генерации
+		if err := validateCount(gen, count); err != nil {
+			printError(os.Stderr, "Ошибка", err)
+			os.Exit(1)
+		}
+
+		// Генерируем пароли
+		ctx := context.Background()
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		var stats password.Stats
+		passwords, stats, err = gen.GenerateUniqueWithStatsContext(ctx, count)
+		if errors.Is(err, context.DeadlineExceeded) {
+			fmt.Fprintf(os.Stderr, "Ошибка: генерация не уложилась в -timeout %s\n", timeout)
+			os.Exit(1)
+		}
+		if err != nil {
+			printError(os.Stderr, "Ошибка генерации паролей", err)
+			os.Exit(1)
+		}
+		rep.reportStats(stats)
+		entropyOf = gen.PasswordEntropy
+	}
+
+	// Перемешиваем порядок, чтобы позиция не выдавала порядок генерации
+	if shuffleOutput {
+		if err := password.ShuffleStrings(passwords); err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Выводим QR-код для сканирования вместо текста
+	if qr {
+		rendered, err := renderQR(passwords[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Print(rendered)
+		return
+	}
+
+	// Копируем в буфер обмена вместо вывода
+	if copyClip {
+		if err := copyPassword(passwords[0], systemClipboard, rep.out()); err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Формируем CSV или JSON вместо текстового вывода
+	if format != "text" {
+		var content string
+		if format == "csv" {
+			content, err = formatCSV(passwords, csvMeta, entropyOf)
+		} else {
+			content, err = formatJSON(config, passwords, jsonPretty)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+			os.Exit(1)
+		}
+		if output != "" {
+			if err := writeSecretFile(output, content); err != nil {
+				fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+				os.Exit(1)
+			}
+			rep.infof("Пароли записаны в %s\n", output)
+			return
+		}
+		fmt.Print(content)
+		return
+	}
+
+	// Форматируем результат
+	var lines []string
+	if card > 0 {
+		lines = formatCard(passwords, style, entropyOf)
+	} else {
+		for _, pwd := range passwords {
+			lines = append(lines, formatLine(pwd, style, entropyOf))
+		}
+	}
+
+	// Записываем в файл вместо вывода
+	if output != "" {
+		if err := writePasswordsFile(output, lines); err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+			os.Exit(1)
+		}
+		rep.infof("Пароли записаны в %s\n", output)
+		return
+	}
+
+	// Дописываем в журнал вместо вывода
+	if appendTo != "" {
+		if err := appendPasswordsFile(appendTo, lines); err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка: %v\n", err)
+			os.Exit(1)
+		}
+		rep.infof("Пароли дописаны в %s\n", appendTo)
+		return
+	}
+
+	// Выводим результат
+	fmt.Print(assembleOutput(lines, delimiter, !noTrailingNewline))
 }
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runMainEnv - переменная окружения, по которой тестовый бинарник
+// выполняет main вместо тестов: так проверяются настоящие stdout и stderr
+const runMainEnv = "PASSWORDGEN_RUN_MAIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(runMainEnv) == "1" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// runCLI запускает программу с аргументами args в отдельном процессе и
+// возвращает содержимое stdout и stderr
+func runCLI(t *testing.T, args ...string) (string, string) {
+	t.Helper()
+
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Env = append(os.Environ(), runMainEnv+"=1")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("passwordgen %v failed: %v\nstderr: %s", args, err, stderr.String())
+	}
+	return stdout.String(), stderr.String()
+}
+
+func TestStdoutContainsOnlyPasswords(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		count      int
+		length     int
+		wantStderr string
+	}{
+		{
+			name:   "обычный вывод",
+			args:   []string{"-length", "12", "-digits", "-lower", "-upper", "-count", "5"},
+			count:  5,
+			length: 12,
+		},
+		{
+			name:       "предупреждение об исчерпании комбинаций",
+			args:       []string{"-length", "2", "-digits", "-count", "60"},
+			count:      60,
+			length:     2,
+			wantStderr: "Предупреждение:",
+		},
+		{
+			name:       "подробный вывод",
+			args:       []string{"-length", "8", "-digits", "-lower", "-count", "3", "-verbose"},
+			count:      3,
+			length:     8,
+			wantStderr: "Конфигурация:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout, stderr := runCLI(t, tt.args...)
+
+			lines := strings.Split(strings.TrimSuffix(stdout, "\n"), "\n")
+			if len(lines) != tt.count {
+				t.Fatalf("stdout has %d lines, want %d:\n%s", len(lines), tt.count, stdout)
+			}
+			for _, line := range lines {
+				if len([]rune(line)) != tt.length || strings.ContainsAny(line, " :") {
+					t.Errorf("stdout line %q is not a password of length %d", line, tt.length)
+				}
+			}
+
+			if tt.wantStderr == "" && stderr != "" {
+				t.Errorf("stderr = %q, want empty", stderr)
+			}
+			if !strings.Contains(stderr, tt.wantStderr) {
+				t.Errorf("stderr = %q, want it to contain %q", stderr, tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestAppendToFileKeepsStdoutEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.txt")
+	stdout, stderr := runCLI(t, "-length", "8", "-lower", "-count", "2", "-append-to-file", path)
+
+	if stdout != "" {
+		t.Errorf("stdout = %q, want empty", stdout)
+	}
+	if !strings.Contains(stderr, "Пароли дописаны в") {
+		t.Errorf("stderr = %q, want confirmation message", stderr)
+	}
+}
+
+func TestConfigWithOnlyCustomGroups(t *testing.T) {
+	// Стандартные наборы отключены, символы берутся только из custom_groups
+	path := filepath.Join(t.TempDir(), "policy.json")
+	policy := `{
+  "length": 6,
+  "use_digits": false,
+  "use_lower": false,
+  "use_upper": false,
+  "custom_groups": [
+    {"name": "vowels", "chars": "aeiou", "min": 2},
+    {"name": "marks", "chars": "~|", "min": 1}
+  ]
+}`
+	if err := os.WriteFile(path, []byte(policy), 0o600); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+
+	stdout, _ := runCLI(t, "-config", path, "-count", "3")
+	lines := strings.Split(strings.TrimSuffix(stdout, "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("stdout has %d lines, want 3:\n%s", len(lines), stdout)
+	}
+	for _, line := range lines {
+		if len(line) != 6 || strings.Trim(line, "aeiou~|") != "" {
+			t.Errorf("password %q is not built from custom groups only", line)
+		}
+	}
+}
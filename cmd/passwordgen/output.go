@@ -0,0 +1,213 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/vikto/passwordgen/internal/password"
+)
+
+// writePasswordsFile записывает пароли в новый файл по одному на строку.
+// Файл создаётся с правами 0600; существующий файл не перезаписывается,
+// чтобы случайно не затереть ранее сохранённые секреты.
+func writePasswordsFile(path string, lines []string) error {
+	return writeSecretFile(path, assembleOutput(lines, "\n", len(lines) > 0))
+}
+
+// writeSecretFile записывает content в новый файл с правами 0600,
+// не перезаписывая существующий
+func writeSecretFile(path string, content string) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return fmt.Errorf("файл %s уже существует", path)
+		}
+		return fmt.Errorf("не удалось создать файл %s: %w", path, err)
+	}
+
+	if _, err := file.WriteString(content); err != nil {
+		file.Close()
+		return fmt.Errorf("не удалось записать в файл %s: %w", path, err)
+	}
+
+	return file.Close()
+}
+
+// appendPasswordsFile дописывает пароли в конец файла по одному на строку,
+// создавая его с правами 0600 при отсутствии. На время записи файл
+// блокируется, чтобы строки одновременно запущенных процессов не
+// перемешивались.
+func appendPasswordsFile(path string, lines []string) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("не удалось открыть файл %s: %w", path, err)
+	}
+	defer file.Close()
+
+	if err := lockFile(file); err != nil {
+		return fmt.Errorf("не удалось заблокировать файл %s: %w", path, err)
+	}
+	defer unlockFile(file)
+
+	if _, err := file.WriteString(assembleOutput(lines, "\n", len(lines) > 0)); err != nil {
+		return fmt.Errorf("не удалось дописать в файл %s: %w", path, err)
+	}
+	return nil
+}
+
+// printEstimate выводит результат оценки выполнимости генерации
+func printEstimate(w io.Writer, result password.EstimateResult) {
+	fmt.Fprintf(w, "Запрошено паролей: %d\n", result.Count)
+	fmt.Fprintf(w, "Максимум уникальных паролей: %s\n", result.MaxUnique)
+	fmt.Fprintf(w, "Энтропия: %.1f бит\n", result.EntropyBits)
+	if result.Feasible {
+		fmt.Fprintln(w, "Генерация выполнима")
+	} else {
+		fmt.Fprintln(w, "Генерация невыполнима: увеличьте длину или набор символов")
+	}
+}
+
+// assembleOutput соединяет строки через delimiter и при trailingNewline
+// добавляет перевод строки в конце
+func assembleOutput(lines []string, delimiter string, trailingNewline bool) string {
+	out := strings.Join(lines, delimiter)
+	if trailingNewline {
+		out += "\n"
+	}
+	return out
+}
+
+// formatCSV формирует CSV с заголовком и строкой на каждый пароль. При metadata
+// добавляются колонки index (с 1), length (в символах) и entropy (бит, через
+// функцию entropy). Кавычки и запятые в паролях экранируются encoding/csv.
+func formatCSV(passwords []string, metadata bool, entropy func(string) float64) (string, error) {
+	var buf strings.Builder
+	w := csv.NewWriter(&buf)
+
+	header := []string{"password"}
+	if metadata {
+		header = []string{"index", "password", "length", "entropy"}
+	}
+	if err := w.Write(header); err != nil {
+		return "", err
+	}
+
+	for i, pwd := range passwords {
+		record := []string{pwd}
+		if metadata {
+			record = []string{
+				strconv.Itoa(i + 1),
+				pwd,
+				strconv.Itoa(utf8.RuneCountInString(pwd)),
+				strconv.FormatFloat(entropy(pwd), 'f', 1, 64),
+			}
+		}
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", fmt.Errorf("не удалось сформировать CSV: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// jsonOutput - тело вывода -format json: конфигурация, по которой
+// созданы пароли, делает вывод самодостаточным для журналов аудита
+type jsonOutput struct {
+	Config    password.Config `json:"config"`
+	Passwords []string        `json:"passwords"`
+}
+
+// formatJSON формирует JSON с конфигурацией config и паролями. При pretty
+// вложенные значения выводятся с отступом в два пробела. Символы <, > и &
+// в паролях не экранируются.
+func formatJSON(config password.Config, passwords []string, pretty bool) (string, error) {
+	var buf strings.Builder
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if pretty {
+		enc.SetIndent("", "  ")
+	}
+	if err := enc.Encode(jsonOutput{Config: config, Passwords: passwords}); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// lineFormat - оформление строки пароля в текстовом выводе
+type lineFormat struct {
+	group       int    // размер групп, 0 - без разбиения
+	groupSep    string // разделитель групп
+	showEntropy bool   // энтропия в битах
+	score       bool   // оценка надёжности
+	analyze     bool   // состав по классам символов
+}
+
+// formatLine оформляет пароль для текстового вывода: разбивает на группы
+// и добавляет выбранные пояснения. Энтропия берётся из функции entropy.
+func formatLine(pwd string, f lineFormat, entropy func(string) float64) string {
+	return groupPassword(pwd, f) + lineNotes(pwd, f, entropy)
+}
+
+// groupPassword разбивает пароль на группы, если это задано в f
+func groupPassword(pwd string, f lineFormat) string {
+	if f.group > 0 {
+		return password.FormatGrouped(pwd, f.group, f.groupSep)
+	}
+	return pwd
+}
+
+// lineNotes возвращает выбранные в f пояснения к паролю, каждое с отступом
+// в два пробела
+func lineNotes(pwd string, f lineFormat, entropy func(string) float64) string {
+	var notes string
+	if f.showEntropy {
+		notes += fmt.Sprintf("  (%.1f bits)", entropy(pwd))
+	}
+	if f.score {
+		value, label := password.Strength(pwd)
+		notes += fmt.Sprintf("  (%d/4, %s)", value, label)
+	}
+	if f.analyze {
+		c := password.Analyze(pwd)
+		notes += fmt.Sprintf("  [цифры: %d, строчные: %d, прописные: %d, символы: %d, другие: %d]",
+			c.Digits, c.Lower, c.Upper, c.Symbols, c.Other)
+	}
+	return notes
+}
+
+// formatCard оформляет пароли как карточку пронумерованных вариантов для
+// выбора: "1) ...", "2) ...". Номера выравниваются по правому краю, а
+// пояснения - по самому длинному паролю, чтобы столбцы совпадали.
+func formatCard(passwords []string, f lineFormat, entropy func(string) float64) []string {
+	numberWidth := len(strconv.Itoa(len(passwords)))
+
+	grouped := make([]string, len(passwords))
+	pwdWidth := 0
+	for i, pwd := range passwords {
+		grouped[i] = groupPassword(pwd, f)
+		pwdWidth = max(pwdWidth, utf8.RuneCountInString(grouped[i]))
+	}
+
+	lines := make([]string, len(passwords))
+	for i, pwd := range passwords {
+		line := fmt.Sprintf("%*d) %s", numberWidth, i+1, grouped[i])
+		if notes := lineNotes(pwd, f, entropy); notes != "" {
+			padding := strings.Repeat(" ", pwdWidth-utf8.RuneCountInString(grouped[i]))
+			line += padding + notes
+		}
+		lines[i] = line
+	}
+	return lines
+}
@@ -0,0 +1,324 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vikto/passwordgen/internal/password"
+)
+
+func TestWritePasswordsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "passwords.txt")
+
+	if err := writePasswordsFile(path, []string{"abc123", "xyz789"}); err != nil {
+		t.Fatalf("writePasswordsFile() failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() failed: %v", err)
+	}
+	if got, want := string(data), "abc123\nxyz789\n"; got != want {
+		t.Errorf("file contents = %q, want %q", got, want)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat() failed: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("file mode = %o, want 600", perm)
+	}
+}
+
+func TestWritePasswordsFileExisting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "passwords.txt")
+	if err := os.WriteFile(path, []byte("old secret\n"), 0600); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+
+	if err := writePasswordsFile(path, []string{"new"}); err == nil {
+		t.Error("Expected error for existing file, got none")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() failed: %v", err)
+	}
+	if string(data) != "old secret\n" {
+		t.Errorf("existing file was modified: %q", data)
+	}
+}
+
+func TestAppendPasswordsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.txt")
+	if err := os.WriteFile(path, []byte("old\n"), 0600); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+
+	// Два последовательных запуска дописывают строки по порядку
+	if err := appendPasswordsFile(path, []string{"abc123", "xyz789"}); err != nil {
+		t.Fatalf("appendPasswordsFile() failed: %v", err)
+	}
+	if err := appendPasswordsFile(path, []string{"qwe456"}); err != nil {
+		t.Fatalf("appendPasswordsFile() failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() failed: %v", err)
+	}
+	if got, want := string(data), "old\nabc123\nxyz789\nqwe456\n"; got != want {
+		t.Errorf("file contents = %q, want %q", got, want)
+	}
+}
+
+func TestAppendPasswordsFileCreates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.txt")
+	if err := appendPasswordsFile(path, []string{"abc123"}); err != nil {
+		t.Fatalf("appendPasswordsFile() failed: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat() failed: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("file mode = %o, want 600", perm)
+	}
+}
+
+func TestPrintEstimate(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   password.EstimateResult
+		contains []string
+	}{
+		{
+			name:     "выполнимо",
+			result:   password.EstimateResult{Count: 10, MaxUnique: big.NewInt(720), EntropyBits: 9.49, Feasible: true},
+			contains: []string{"10", "720", "9.5 бит", "выполнима"},
+		},
+		{
+			name:     "невыполнимо",
+			result:   password.EstimateResult{Count: 1000, MaxUnique: big.NewInt(720), EntropyBits: 9.49},
+			contains: []string{"1000", "720", "невыполнима"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			printEstimate(&buf, tt.result)
+			for _, want := range tt.contains {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output %q does not contain %q", buf.String(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestAssembleOutput(t *testing.T) {
+	tests := []struct {
+		name      string
+		lines     []string
+		delimiter string
+		trailing  bool
+		want      string
+	}{
+		{name: "по умолчанию", lines: []string{"a1", "b2", "c3"}, delimiter: "\n", trailing: true, want: "a1\nb2\nc3\n"},
+		{name: "без перевода строки", lines: []string{"a1"}, delimiter: "\n", trailing: false, want: "a1"},
+		{name: "запятая", lines: []string{"a1", "b2"}, delimiter: ",", trailing: true, want: "a1,b2\n"},
+		{name: "пробел без перевода строки", lines: []string{"a1", "b2"}, delimiter: " ", trailing: false, want: "a1 b2"},
+		{name: "пустой разделитель", lines: []string{"a1", "b2"}, delimiter: "", trailing: false, want: "a1b2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := assembleOutput(tt.lines, tt.delimiter, tt.trailing); got != tt.want {
+				t.Errorf("assembleOutput() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatCSV(t *testing.T) {
+	passwords := []string{"abc123", `a,b"c`, "x y;z"}
+	entropy := func(pwd string) float64 { return float64(len(pwd)) * 1.5 }
+
+	tests := []struct {
+		name     string
+		metadata bool
+		want     [][]string
+	}{
+		{
+			name:     "только пароли",
+			metadata: false,
+			want:     [][]string{{"password"}, {"abc123"}, {`a,b"c`}, {"x y;z"}},
+		},
+		{
+			name:     "с метаданными",
+			metadata: true,
+			want: [][]string{
+				{"index", "password", "length", "entropy"},
+				{"1", "abc123", "6", "9.0"},
+				{"2", `a,b"c`, "5", "7.5"},
+				{"3", "x y;z", "5", "7.5"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := formatCSV(passwords, tt.metadata, entropy)
+			if err != nil {
+				t.Fatalf("formatCSV() failed: %v", err)
+			}
+
+			records, err := csv.NewReader(strings.NewReader(table)).ReadAll()
+			if err != nil {
+				t.Fatalf("ReadAll() failed: %v", err)
+			}
+			if !reflect.DeepEqual(records, tt.want) {
+				t.Errorf("parsed CSV = %q, want %q", records, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatCSVQuoting(t *testing.T) {
+	table, err := formatCSV([]string{`a,b"c`}, false, nil)
+	if err != nil {
+		t.Fatalf("formatCSV() failed: %v", err)
+	}
+	if want := "password\n\"a,b\"\"c\"\n"; table != want {
+		t.Errorf("formatCSV() = %q, want %q", table, want)
+	}
+}
+
+func TestFormatLine(t *testing.T) {
+	all, err := password.NewGenerator(password.Config{Length: 8, UseDigits: true, UseLower: true, UseUpper: true, UseSymbols: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+	entropy := all.PasswordEntropy
+
+	tests := []struct {
+		name   string
+		pwd    string
+		format lineFormat
+		want   string
+	}{
+		{name: "без пояснений", pwd: "Xk9#mPq2", want: "Xk9#mPq2"},
+		{name: "энтропия", pwd: "Xk9#mPq2", format: lineFormat{showEntropy: true}, want: "Xk9#mPq2  (51.1 bits)"},
+		{name: "энтропия длины 12", pwd: "Xk9#mPq2a!B7", format: lineFormat{showEntropy: true}, want: "Xk9#mPq2a!B7  (76.2 bits)"},
+		{name: "группы и энтропия", pwd: "Xk9#mPq2", format: lineFormat{group: 4, groupSep: "-", showEntropy: true}, want: "Xk9#-mPq2  (51.1 bits)"},
+		{name: "энтропия и оценка", pwd: "Xk9#mPq2", format: lineFormat{showEntropy: true, score: true}, want: "Xk9#mPq2  (51.1 bits)  (3/4, strong)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatLine(tt.pwd, tt.format, entropy); got != tt.want {
+				t.Errorf("formatLine() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatCard(t *testing.T) {
+	constant := func(string) float64 { return 40 }
+	ten := []string{"aB3", "kP9", "zQ1", "mN7", "xY2", "cD4", "eF5", "gH6", "iJ8", "lM0"}
+
+	tests := []struct {
+		name      string
+		passwords []string
+		format    lineFormat
+		want      []string
+	}{
+		{
+			name:      "один вариант",
+			passwords: []string{"aB3kP9"},
+			want:      []string{"1) aB3kP9"},
+		},
+		{
+			name:      "номера выравниваются по правому краю",
+			passwords: ten,
+			want: []string{
+				" 1) aB3", " 2) kP9", " 3) zQ1", " 4) mN7", " 5) xY2",
+				" 6) cD4", " 7) eF5", " 8) gH6", " 9) iJ8", "10) lM0",
+			},
+		},
+		{
+			name:      "пояснения выравниваются по самому длинному паролю",
+			passwords: []string{"aB3kP9", "zQ1mN7xY", "cD4"},
+			format:    lineFormat{showEntropy: true},
+			want: []string{
+				"1) aB3kP9    (40.0 bits)",
+				"2) zQ1mN7xY  (40.0 bits)",
+				"3) cD4       (40.0 bits)",
+			},
+		},
+		{
+			name:      "группы",
+			passwords: []string{"aB3kP9", "zQ1mN7"},
+			format:    lineFormat{group: 3, groupSep: "-"},
+			want:      []string{"1) aB3-kP9", "2) zQ1-mN7"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formatCard(tt.passwords, tt.format, constant)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("formatCard() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatJSON(t *testing.T) {
+	config := password.Config{Length: 8, UseDigits: true, UseSymbols: true}
+	passwords := []string{"1<2>&3#4", "9!8@7$6%"}
+
+	pretty, err := formatJSON(config, passwords, true)
+	if err != nil {
+		t.Fatalf("formatJSON() failed: %v", err)
+	}
+
+	var decoded struct {
+		Config    map[string]any `json:"config"`
+		Passwords []string       `json:"passwords"`
+	}
+	if err := json.Unmarshal([]byte(pretty), &decoded); err != nil {
+		t.Fatalf("Unmarshal() failed: %v\n%s", err, pretty)
+	}
+	if decoded.Config["length"] != float64(8) || decoded.Config["use_digits"] != true || decoded.Config["use_lower"] != false {
+		t.Errorf("config = %v, want the effective configuration", decoded.Config)
+	}
+	if !reflect.DeepEqual(decoded.Passwords, passwords) {
+		t.Errorf("passwords = %q, want %q", decoded.Passwords, passwords)
+	}
+
+	// Отступ в два пробела на каждый уровень вложенности
+	for _, want := range []string{"{\n  \"config\": {\n    \"length\": 8,", "  \"passwords\": [\n    \"1<2>&3#4\",\n    \"9!8@7$6%\"\n  ]\n}\n"} {
+		if !strings.Contains(pretty, want) {
+			t.Errorf("formatJSON() pretty output missing %q:\n%s", want, pretty)
+		}
+	}
+
+	compact, err := formatJSON(config, passwords, false)
+	if err != nil {
+		t.Fatalf("formatJSON() failed: %v", err)
+	}
+	if strings.Count(compact, "\n") != 1 || !strings.HasPrefix(compact, `{"config":{"length":8,`) {
+		t.Errorf("formatJSON() compact output = %q, want a single line", compact)
+	}
+}
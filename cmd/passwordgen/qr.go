@@ -0,0 +1,20 @@
+package main
+
+import (
+	"fmt"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// renderQR кодирует текст в QR-код и возвращает его в виде строк из
+// полублочных символов Unicode (две строки модулей на строку текста).
+// Рисунок рассчитан на тёмный фон терминала: светлые модули выводятся
+// заполненными блоками, тёмные - пробелами.
+func renderQR(text string) (string, error) {
+	code, err := qrcode.New(text, qrcode.Medium)
+	if err != nil {
+		return "", fmt.Errorf("не удалось построить QR-код: %w", err)
+	}
+
+	return code.ToSmallString(false), nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/makiuchi-d/gozxing"
+	"github.com/makiuchi-d/gozxing/qrcode"
+)
+
+// qrImage восстанавливает изображение QR-кода из вывода renderQR:
+// каждый символ кодирует два модуля по вертикали, scale - пикселей на модуль
+func qrImage(t *testing.T, rendered string, scale int) image.Image {
+	t.Helper()
+
+	lines := strings.Split(strings.TrimSuffix(rendered, "\n"), "\n")
+	width := len([]rune(lines[0]))
+	img := image.NewGray(image.Rect(0, 0, width*scale, len(lines)*2*scale))
+
+	for row, line := range lines {
+		for col, r := range []rune(line) {
+			var top, bottom bool // true - светлый модуль
+			switch r {
+			case '█':
+				top, bottom = true, true
+			case '▀':
+				top, bottom = true, false
+			case '▄':
+				top, bottom = false, true
+			case ' ':
+			default:
+				t.Fatalf("unexpected rune %q in QR output", r)
+			}
+			// Нечётная последняя строка: нижней половины нет, считаем её светлой
+			if row == len(lines)-1 && r == '▀' && len(lines) > 1 {
+				bottom = true
+			}
+
+			for i, light := range []bool{top, bottom} {
+				c := color.Gray{Y: 0}
+				if light {
+					c = color.Gray{Y: 255}
+				}
+				for dy := 0; dy < scale; dy++ {
+					for dx := 0; dx < scale; dx++ {
+						img.SetGray(col*scale+dx, (row*2+i)*scale+dy, c)
+					}
+				}
+			}
+		}
+	}
+	return img
+}
+
+func TestRenderQRDecodable(t *testing.T) {
+	for _, text := range []string{"Hg6Bn0Sk9Wu4", "p@ss w0rd-ж!{}"} {
+		rendered, err := renderQR(text)
+		if err != nil {
+			t.Fatalf("renderQR() failed: %v", err)
+		}
+
+		bitmap, err := gozxing.NewBinaryBitmapFromImage(qrImage(t, rendered, 4))
+		if err != nil {
+			t.Fatalf("NewBinaryBitmapFromImage() failed: %v", err)
+		}
+
+		result, err := qrcode.NewQRCodeReader().Decode(bitmap, nil)
+		if err != nil {
+			t.Fatalf("Decode() failed: %v", err)
+		}
+		if got := result.GetText(); got != text {
+			t.Errorf("decoded QR = %q, want %q", got, text)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/vikto/passwordgen/internal/password"
+)
+
+// maxServeCount ограничивает число паролей в одном HTTP-запросе
+const maxServeCount = 1000
+
+// passwordResponse - тело успешного ответа GET /password
+type passwordResponse struct {
+	Passwords []string `json:"passwords"`
+}
+
+// errorResponse - тело ответа с ошибкой
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// runServer запускает HTTP-сервер подкоманды serve с аргументами args
+func runServer(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", "localhost:8080", "Адрес для входящих подключений")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/password", handlePassword)
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	fmt.Fprintf(os.Stderr, "Сервер запущен на %s\n", *addr)
+	return server.ListenAndServe()
+}
+
+// handlePassword обрабатывает GET /password?length=16&sets=dlu&count=5.
+// Параметры проверяются так же, как в командной строке: обязательная
+// положительная длина, хотя бы один набор и count не больше числа возможных
+// уникальных паролей. При ошибке в параметрах возвращается 400.
+func handlePassword(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "поддерживается только метод GET"})
+		return
+	}
+
+	gen, count, err := parsePasswordQuery(r)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+		return
+	}
+
+	passwords, err := gen.GenerateUnique(count)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: err.Error()})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, passwordResponse{Passwords: passwords})
+}
+
+// parsePasswordQuery создаёт генератор по параметрам запроса и возвращает
+// запрошенное количество паролей
+func parsePasswordQuery(r *http.Request) (*password.Generator, int, error) {
+	query := r.URL.Query()
+
+	length, err := strconv.Atoi(query.Get("length"))
+	if err != nil || length <= 0 {
+		return nil, 0, errors.New("длина должна быть положительным числом")
+	}
+
+	config := password.Config{Length: length}
+	sets := query.Get("sets")
+	if sets == "" {
+		return nil, 0, errors.New("необходимо выбрать хотя бы один набор символов (d, l, u, s)")
+	}
+	if err := applySets(&config, sets); err != nil {
+		return nil, 0, err
+	}
+
+	count := 1
+	if text := query.Get("count"); text != "" {
+		if count, err = strconv.Atoi(text); err != nil {
+			return nil, 0, fmt.Errorf("некорректное количество паролей %q", text)
+		}
+	}
+	if count > maxServeCount {
+		return nil, 0, fmt.Errorf("за один запрос можно получить не больше %d паролей", maxServeCount)
+	}
+
+	gen, err := password.NewGenerator(config)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := validateCount(gen, count); err != nil {
+		return nil, 0, err
+	}
+
+	return gen, count, nil
+}
+
+// writeJSON записывает v в ответ с кодом status. Ответы содержат секреты,
+// поэтому кэширование запрещено.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.Encode(v)
+}
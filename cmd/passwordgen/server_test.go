@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestHandlePassword(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/password?length=16&sets=dlu&count=5", nil)
+	rec := httptest.NewRecorder()
+	handlePassword(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+	if got := rec.Header().Get("Content-Type"); !strings.HasPrefix(got, "application/json") {
+		t.Errorf("Content-Type = %q, want application/json", got)
+	}
+	if got := rec.Header().Get("Cache-Control"); got != "no-store" {
+		t.Errorf("Cache-Control = %q, want no-store", got)
+	}
+
+	var resp passwordResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("Decode() failed: %v", err)
+	}
+	if len(resp.Passwords) != 5 {
+		t.Fatalf("got %d passwords, want 5", len(resp.Passwords))
+	}
+
+	seen := make(map[string]bool)
+	for _, pwd := range resp.Passwords {
+		if utf8.RuneCountInString(pwd) != 16 {
+			t.Errorf("Password %q has length %d, want 16", pwd, utf8.RuneCountInString(pwd))
+		}
+		if !strings.ContainsAny(pwd, "0123456789") || !strings.ContainsAny(pwd, "abcdefghijklmnopqrstuvwxyz") || !strings.ContainsAny(pwd, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") {
+			t.Errorf("Password %q does not contain every requested set", pwd)
+		}
+		if seen[pwd] {
+			t.Errorf("Duplicate password %q", pwd)
+		}
+		seen[pwd] = true
+	}
+}
+
+func TestHandlePasswordBadRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		wantMsg string
+	}{
+		{name: "без длины", query: "sets=dlu", wantMsg: "длина должна быть положительным числом"},
+		{name: "нечисловая длина", query: "length=abc&sets=d", wantMsg: "длина должна быть положительным числом"},
+		{name: "отрицательная длина", query: "length=-4&sets=d", wantMsg: "длина должна быть положительным числом"},
+		{name: "пустой набор", query: "length=8&sets=", wantMsg: "хотя бы один набор"},
+		{name: "неизвестный набор", query: "length=8&sets=dx", wantMsg: "неизвестный набор символов"},
+		{name: "длина больше набора", query: "length=11&sets=d", wantMsg: "длина пароля"},
+		{name: "некорректное количество", query: "length=8&sets=d&count=много", wantMsg: "некорректное количество"},
+		{name: "слишком много паролей", query: "length=3&sets=d&count=721", wantMsg: "только 720 уникальных паролей"},
+		{name: "лимит запроса", query: "length=16&sets=dlu&count=5000", wantMsg: "не больше 1000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/password?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			handlePassword(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+			}
+
+			var resp errorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("Decode() failed: %v", err)
+			}
+			if !strings.Contains(resp.Error, tt.wantMsg) {
+				t.Errorf("error = %q, want it to contain %q", resp.Error, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestHandlePasswordMethod(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/password?length=8&sets=d", nil)
+	rec := httptest.NewRecorder()
+	handlePassword(rec, req)
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+	if got := rec.Header().Get("Allow"); got != http.MethodGet {
+		t.Errorf("Allow = %q, want GET", got)
+	}
+}
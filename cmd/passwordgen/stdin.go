@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/vikto/passwordgen/internal/password"
+)
+
+// runStdinCount читает из in по одному числу на строке и для каждого
+// выводит в out столько паролей, по одному на строку, отделяя группы пустой
+// строкой. Все группы создаёт один генератор gen, поэтому пароли уникальны
+// в пределах всего ввода. Каждая группа выводится сразу после генерации,
+// чтобы управляющий процесс мог читать ответ на каждую строку. Пустые строки
+// пропускаются, некорректное число завершает работу с ошибкой.
+func runStdinCount(in io.Reader, out io.Writer, gen *password.Generator, format func(string) string) error {
+	scanner := bufio.NewScanner(in)
+	first := true
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		count, err := strconv.Atoi(text)
+		if err != nil || count <= 0 {
+			return fmt.Errorf("строка %d: количество паролей должно быть положительным числом, получено %q", lineNum, text)
+		}
+
+		passwords, err := gen.GenerateUnique(count)
+		if err != nil {
+			return fmt.Errorf("строка %d: %w", lineNum, err)
+		}
+
+		var group strings.Builder
+		if !first {
+			group.WriteString("\n")
+		}
+		for _, pwd := range passwords {
+			group.WriteString(format(pwd))
+			group.WriteString("\n")
+		}
+		if _, err := io.WriteString(out, group.String()); err != nil {
+			return err
+		}
+		first = false
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/vikto/passwordgen/internal/password"
+)
+
+func TestRunStdinCount(t *testing.T) {
+	gen, err := password.NewGenerator(password.Config{Length: 10, UseLower: true, UseDigits: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	// Пустые строки и пробелы вокруг чисел пропускаются
+	input := "3\n\n 1 \n2\n"
+	var out bytes.Buffer
+	if err := runStdinCount(strings.NewReader(input), &out, gen, func(pwd string) string { return pwd }); err != nil {
+		t.Fatalf("runStdinCount() failed: %v", err)
+	}
+
+	groups := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n\n")
+	wantSizes := []int{3, 1, 2}
+	if len(groups) != len(wantSizes) {
+		t.Fatalf("got %d groups, want %d:\n%s", len(groups), len(wantSizes), out.String())
+	}
+
+	seen := make(map[string]bool)
+	for i, group := range groups {
+		lines := strings.Split(group, "\n")
+		if len(lines) != wantSizes[i] {
+			t.Errorf("group %d has %d passwords, want %d", i+1, len(lines), wantSizes[i])
+		}
+		for _, pwd := range lines {
+			if utf8.RuneCountInString(pwd) != 10 {
+				t.Errorf("Password %q has length %d, want 10", pwd, utf8.RuneCountInString(pwd))
+			}
+			// Один генератор на весь ввод - пароли уникальны между группами
+			if seen[pwd] {
+				t.Errorf("Duplicate password %q", pwd)
+			}
+			seen[pwd] = true
+		}
+	}
+}
+
+func TestRunStdinCountFormat(t *testing.T) {
+	gen, err := password.NewGenerator(password.Config{Length: 8, UseDigits: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	var out bytes.Buffer
+	format := func(pwd string) string { return formatLine(pwd, lineFormat{group: 4, groupSep: "-"}, nil) }
+	if err := runStdinCount(strings.NewReader("1\n1"), &out, gen, format); err != nil {
+		t.Fatalf("runStdinCount() failed: %v", err)
+	}
+
+	lines := strings.Split(out.String(), "\n")
+	if len(lines) != 4 || lines[1] != "" || lines[3] != "" {
+		t.Fatalf("output = %q, want two single-password groups", out.String())
+	}
+	for _, line := range []string{lines[0], lines[2]} {
+		if len(line) != 9 || line[4] != '-' {
+			t.Errorf("line %q is not grouped as 4-4", line)
+		}
+	}
+}
+
+func TestRunStdinCountErrors(t *testing.T) {
+	gen, err := password.NewGenerator(password.Config{Length: 2, UseDigits: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		wantMsg string
+	}{
+		{name: "не число", input: "2\nмного\n", wantMsg: "строка 2"},
+		{name: "ноль", input: "0\n", wantMsg: "строка 1"},
+		{name: "больше возможного", input: "5\n100\n", wantMsg: "строка 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := runStdinCount(strings.NewReader(tt.input), &out, gen, func(pwd string) string { return pwd })
+			if err == nil || !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("runStdinCount() error = %v, want it to contain %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/vikto/passwordgen/internal/password"
+)
+
+// suggestions сопоставляет причину ошибки генератора с советом, как её
+// исправить. Порядок важен: ошибка может иметь несколько причин, и
+// выбирается первая подходящая.
+var suggestions = []struct {
+	kind error
+	text string
+}{
+	{password.ErrCharsetExhausted, "увеличьте -length или включите больше наборов символов, чтобы увеличить число возможных паролей"},
+	{password.ErrInvalidCount, "укажите в -count положительное число"},
+	{password.ErrInvalidConfig, "проверьте длину и наборы символов; список флагов выводит -help"},
+	{password.ErrRandom, "системный источник случайности недоступен, повторите попытку позже"},
+}
+
+// suggestion возвращает совет для ошибки или пустую строку, если причина
+// ошибки неизвестна
+func suggestion(err error) string {
+	for _, s := range suggestions {
+		if errors.Is(err, s.kind) {
+			return s.text
+		}
+	}
+	return ""
+}
+
+// printError выводит ошибку с префиксом и, если он есть, совет по её
+// исправлению отдельной строкой
+func printError(w io.Writer, prefix string, err error) {
+	fmt.Fprintf(w, "%s: %v\n", prefix, err)
+	if hint := suggestion(err); hint != "" {
+		fmt.Fprintf(w, "Подсказка: %s\n", hint)
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/vikto/passwordgen/internal/password"
+)
+
+func TestSuggestion(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "исчерпание комбинаций",
+			err:  fmt.Errorf("строка 1: %w", password.ErrCharsetExhausted),
+			want: "увеличьте -length или включите больше наборов символов, чтобы увеличить число возможных паролей",
+		},
+		{
+			name: "некорректное количество",
+			err:  password.ErrInvalidCount,
+			want: "укажите в -count положительное число",
+		},
+		{
+			name: "некорректная конфигурация",
+			err:  password.ErrInvalidConfig,
+			want: "проверьте длину и наборы символов; список флагов выводит -help",
+		},
+		{
+			name: "сбой случайности",
+			err:  password.ErrRandom,
+			want: "системный источник случайности недоступен, повторите попытку позже",
+		},
+		{
+			name: "неизвестная причина",
+			err:  context.DeadlineExceeded,
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := suggestion(tt.err); got != tt.want {
+				t.Errorf("suggestion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuggestionFromGenerator(t *testing.T) {
+	gen, err := password.NewGenerator(password.Config{Length: 3, UseDigits: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	err = validateCount(gen, 1000)
+	if !errors.Is(err, password.ErrCharsetExhausted) {
+		t.Fatalf("validateCount() error = %v, want ErrCharsetExhausted", err)
+	}
+
+	var buf bytes.Buffer
+	printError(&buf, "Ошибка", err)
+	want := "Ошибка: " + err.Error() + "\nПодсказка: " + suggestion(err) + "\n"
+	if buf.String() != want {
+		t.Errorf("printError() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestPrintErrorWithoutSuggestion(t *testing.T) {
+	var buf bytes.Buffer
+	printError(&buf, "Ошибка", errors.New("файл не найден"))
+	if got, want := buf.String(), "Ошибка: файл не найден\n"; got != want {
+		t.Errorf("printError() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/vikto/passwordgen/internal/password"
+)
+
+// verbosity определяет, какие служебные сообщения выводятся в stderr
+type verbosity int
+
+const (
+	normalOutput  verbosity = iota // сообщения и предупреждения
+	quietOutput                    // только пароли и ошибки
+	verboseOutput                  // дополнительно конфигурация и статистика
+)
+
+// parseVerbosity выбирает уровень вывода по флагам -quiet и -verbose
+func parseVerbosity(quiet, verbose bool) (verbosity, error) {
+	switch {
+	case quiet && verbose:
+		return normalOutput, fmt.Errorf("-quiet и -verbose нельзя использовать вместе")
+	case quiet:
+		return quietOutput, nil
+	case verbose:
+		return verboseOutput, nil
+	}
+	return normalOutput, nil
+}
+
+// reporter выводит служебные сообщения в w с учётом уровня вывода.
+// Пароли и ошибки выводятся напрямую и от уровня не зависят.
+type reporter struct {
+	level verbosity
+	w     io.Writer
+}
+
+// out возвращает поток для обычных сообщений: в тихом режиме они отбрасываются
+func (r reporter) out() io.Writer {
+	if r.level == quietOutput {
+		return io.Discard
+	}
+	return r.w
+}
+
+// infof выводит обычное сообщение, если не включён тихий режим
+func (r reporter) infof(format string, args ...any) {
+	fmt.Fprintf(r.out(), format, args...)
+}
+
+// debugf выводит подробное сообщение только в режиме -verbose
+func (r reporter) debugf(format string, args ...any) {
+	if r.level == verboseOutput {
+		fmt.Fprintf(r.w, format, args...)
+	}
+}
+
+// usage выводит справку по флагам, если не включён тихий режим
+func (r reporter) usage() {
+	if r.level != quietOutput {
+		flag.Usage()
+	}
+}
+
+// reportConfig выводит итоговую конфигурацию и её энтропию (только -verbose)
+func (r reporter) reportConfig(config password.Config, entropy float64) {
+	r.debugf("Конфигурация: %s\n", describeConfig(config))
+	r.debugf("Энтропия: %.1f бит (время подбора: %s)\n", entropy, password.CrackTimeString(entropy, password.DefaultGuessesPerSecond))
+}
+
+// reportStats выводит статистику генерации (только -verbose) и
+// предупреждение об исчерпании комбинаций (кроме -quiet)
+func (r reporter) reportStats(stats password.Stats) {
+	r.debugf("Попыток генерации: %d\n", stats.Attempts)
+	r.debugf("Энтропия паролей: мин. %.1f, средн. %.1f, макс. %.1f бит\n",
+		stats.MinEntropy, stats.MeanEntropy, stats.MaxEntropy)
+	if stats.Warning != "" {
+		r.infof("Предупреждение: %s\n", stats.Warning)
+	}
+}
+
+// progressMinCount - размер пачки, начиная с которого -verbose показывает прогресс
+const progressMinCount = 10000
+
+// progress выводит процент готовых паролей в одну обновляемую строку
+// (только -verbose)
+func (r reporter) progress(done, total int) {
+	r.debugf("\rСгенерировано: %d%%", done*100/total)
+	if done == total {
+		r.debugf("\n")
+	}
+}
+
+// describeConfig возвращает краткое описание конфигурации в одну строку
+func describeConfig(config password.Config) string {
+	var parts []string
+
+	if config.Length > 0 {
+		parts = append(parts, fmt.Sprintf("длина %d", config.Length))
+	} else {
+		parts = append(parts, fmt.Sprintf("длина %d-%d", config.MinLength, config.MaxLength))
+	}
+
+	var sets []string
+	for _, set := range []struct {
+		enabled bool
+		name    string
+	}{
+		{config.UseDigits, "digits"},
+		{config.UseLower, "lower"},
+		{config.UseUpper, "upper"},
+		{config.UseSymbols, "symbols"},
+		{config.UseEmoji, "emoji"},
+		{config.CustomChars != "", "custom"},
+	} {
+		if set.enabled {
+			sets = append(sets, set.name)
+		}
+	}
+	parts = append(parts, "наборы: "+strings.Join(sets, ", "))
+
+	if config.ExcludeChars != "" {
+		parts = append(parts, fmt.Sprintf("исключено: %q", config.ExcludeChars))
+	}
+
+	repeats := "нет"
+	if config.AllowRepeats {
+		repeats = "да"
+	}
+	parts = append(parts, "повторы: "+repeats)
+
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/vikto/passwordgen/internal/password"
+)
+
+func TestParseVerbosity(t *testing.T) {
+	tests := []struct {
+		name    string
+		quiet   bool
+		verbose bool
+		want    verbosity
+		wantErr bool
+	}{
+		{name: "по умолчанию", want: normalOutput},
+		{name: "quiet", quiet: true, want: quietOutput},
+		{name: "verbose", verbose: true, want: verboseOutput},
+		{name: "оба флага", quiet: true, verbose: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseVerbosity(tt.quiet, tt.verbose)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseVerbosity() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseVerbosity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReporterLevels(t *testing.T) {
+	config := password.Config{Length: 12, UseDigits: true, UseLower: true, ExcludeChars: "0o"}
+	stats := password.Stats{Attempts: 7, MinEntropy: 60, MaxEntropy: 60, MeanEntropy: 60, Warning: "мало комбинаций"}
+
+	tests := []struct {
+		name        string
+		level       verbosity
+		wantInfo    bool
+		wantVerbose bool
+	}{
+		{name: "обычный", level: normalOutput, wantInfo: true, wantVerbose: false},
+		{name: "quiet", level: quietOutput, wantInfo: false, wantVerbose: false},
+		{name: "verbose", level: verboseOutput, wantInfo: true, wantVerbose: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stderr bytes.Buffer
+			rep := reporter{level: tt.level, w: &stderr}
+
+			rep.infof("Пароли записаны в %s\n", "out.txt")
+			rep.reportConfig(config, 62.5)
+			rep.reportStats(stats)
+			got := stderr.String()
+
+			if strings.Contains(got, "out.txt") != tt.wantInfo {
+				t.Errorf("info message present = %v, want %v; stderr = %q", !tt.wantInfo, tt.wantInfo, got)
+			}
+			if strings.Contains(got, "мало комбинаций") != tt.wantInfo {
+				t.Errorf("warning present = %v, want %v; stderr = %q", !tt.wantInfo, tt.wantInfo, got)
+			}
+			for _, want := range []string{"длина 12", "digits, lower", "62.5 бит", "Попыток генерации: 7"} {
+				if strings.Contains(got, want) != tt.wantVerbose {
+					t.Errorf("%q present = %v, want %v; stderr = %q", want, !tt.wantVerbose, tt.wantVerbose, got)
+				}
+			}
+			if tt.level == quietOutput && got != "" {
+				t.Errorf("quiet stderr = %q, want empty", got)
+			}
+		})
+	}
+}
+
+func TestDescribeConfig(t *testing.T) {
+	tests := []struct {
+		config password.Config
+		want   string
+	}{
+		{
+			config: password.Config{Length: 8, UseDigits: true},
+			want:   "длина 8; наборы: digits; повторы: нет",
+		},
+		{
+			config: password.Config{MinLength: 10, MaxLength: 14, UseLower: true, CustomChars: "ж", ExcludeChars: "l", AllowRepeats: true},
+			want:   `длина 10-14; наборы: lower, custom; исключено: "l"; повторы: да`,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := describeConfig(tt.config); got != tt.want {
+			t.Errorf("describeConfig() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestReporterProgress(t *testing.T) {
+	var stderr bytes.Buffer
+	rep := reporter{level: verboseOutput, w: &stderr}
+
+	gen, err := password.NewGenerator(password.Config{Length: 8, UseLower: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+	if _, err := gen.GenerateUniqueWithProgress(200, rep.progress); err != nil {
+		t.Fatalf("GenerateUniqueWithProgress() failed: %v", err)
+	}
+
+	got := stderr.String()
+	if !strings.HasPrefix(got, "\rСгенерировано: 1%\r") || !strings.HasSuffix(got, "\rСгенерировано: 100%\n") {
+		t.Errorf("progress output = %q, want updates from 1%% to 100%%", got)
+	}
+
+	// Без -verbose прогресс не выводится
+	stderr.Reset()
+	rep.level = normalOutput
+	rep.progress(5, 10)
+	rep.progress(10, 10)
+	if stderr.Len() != 0 {
+		t.Errorf("progress output without -verbose = %q, want empty", stderr.String())
+	}
+}
@@ -0,0 +1,19 @@
+package password
+
+import "unicode/utf8"
+
+// withAffixes добавляет к случайной части пароля префикс и суффикс из конфигурации
+func (g *Generator) withAffixes(core string) string {
+	if g.prefix == "" && g.suffix == "" {
+		return core
+	}
+	return g.prefix + core + g.suffix
+}
+
+// PasswordEntropy возвращает энтропию сгенерированного пароля в битах.
+// Префикс и суффикс известны заранее и не добавляют энтропии, поэтому
+// учитывается только длина случайной части.
+func (g *Generator) PasswordEntropy(password string) float64 {
+	length := utf8.RuneCountInString(password) - utf8.RuneCountInString(g.prefix) - utf8.RuneCountInString(g.suffix)
+	return g.EntropyForLength(length)
+}
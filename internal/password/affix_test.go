@@ -0,0 +1,127 @@
+package password
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestGenerateAffixes(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{name: "префикс", config: Config{Length: 8, UseDigits: true, UseLower: true, Prefix: "Temp-"}},
+		{name: "суффикс", config: Config{Length: 6, UseUpper: true, Suffix: "!2024"}},
+		{name: "префикс и суффикс", config: Config{Length: 10, UseDigits: true, UseLower: true, Prefix: "Пароль:", Suffix: "#"}},
+		{name: "диапазон длин", config: Config{MinLength: 4, MaxLength: 8, UseLower: true, Prefix: "id-"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen, err := NewGenerator(tt.config)
+			if err != nil {
+				t.Fatalf("NewGenerator() failed: %v", err)
+			}
+
+			passwords, err := gen.GenerateUnique(50)
+			if err != nil {
+				t.Fatalf("GenerateUnique() failed: %v", err)
+			}
+
+			for _, password := range passwords {
+				if !strings.HasPrefix(password, tt.config.Prefix) || !strings.HasSuffix(password, tt.config.Suffix) {
+					t.Errorf("Password %q lacks prefix %q or suffix %q", password, tt.config.Prefix, tt.config.Suffix)
+					continue
+				}
+
+				core := strings.TrimSuffix(strings.TrimPrefix(password, tt.config.Prefix), tt.config.Suffix)
+				length := utf8.RuneCountInString(core)
+				if tt.config.Length > 0 && length != tt.config.Length {
+					t.Errorf("Password %q has core length %d, want %d", password, length, tt.config.Length)
+				}
+				if tt.config.Length == 0 && (length < tt.config.MinLength || length > tt.config.MaxLength) {
+					t.Errorf("Password %q has core length %d outside [%d, %d]", password, length, tt.config.MinLength, tt.config.MaxLength)
+				}
+				if err := gen.Validate(password); err != nil {
+					t.Errorf("Validate(%q) = %v", password, err)
+				}
+			}
+		})
+	}
+}
+
+func TestAffixesCoreOnly(t *testing.T) {
+	// Префикс повторяет символы набора, но запрет повторов действует только
+	// на случайную часть, а энтропия не учитывает префикс
+	config := Config{Length: 10, UseDigits: true, Prefix: "000-"}
+	gen, err := NewGenerator(config)
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	password, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	core := strings.TrimPrefix(password, "000-")
+	for _, d := range digits {
+		if strings.Count(core, string(d)) != 1 {
+			t.Errorf("Core %q should contain each digit exactly once", core)
+		}
+	}
+
+	plain, err := NewGenerator(Config{Length: 10, UseDigits: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+	if gen.Entropy() != plain.Entropy() || gen.PasswordEntropy(password) != plain.Entropy() {
+		t.Errorf("Entropy() = %.2f, PasswordEntropy() = %.2f, want %.2f without prefix",
+			gen.Entropy(), gen.PasswordEntropy(password), plain.Entropy())
+	}
+}
+
+func TestValidateAffixes(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 4, UseLower: true, Prefix: "T-", Suffix: "!"})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	tests := []struct {
+		password string
+		wantErr  bool
+	}{
+		{password: "T-abcd!", wantErr: false},
+		{password: "abcd!", wantErr: true},
+		{password: "T-abcd", wantErr: true},
+		{password: "T-abc!", wantErr: true},
+		{password: "T-!", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		if err := gen.Validate(tt.password); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q) error = %v, wantErr %v", tt.password, err, tt.wantErr)
+		}
+	}
+}
+
+func TestBlocklistWithAffixes(t *testing.T) {
+	// Запрещённые пароли сравниваются целиком, вместе с префиксом
+	blocked := []string{"T-1", "T-2", "T-3"}
+	gen, err := NewGenerator(Config{Length: 1, UseDigits: true, Prefix: "T-", Blocklist: blocked})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	passwords, err := gen.GenerateUnique(7)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+	for _, password := range passwords {
+		for _, b := range blocked {
+			if password == b {
+				t.Errorf("Blocked password %q was generated", password)
+			}
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package password
+
+// classOf возвращает индекс набора charsets, которому принадлежит символ,
+// или -1, если символ не входит ни в один набор
+func (g *Generator) classOf(r rune) int {
+	for i, group := range g.charsets {
+		if containsRune(group, r) {
+			return i
+		}
+	}
+	return -1
+}
+
+// hasAdjacentClass проверяет, есть ли в пароле соседние символы из одного набора
+func (g *Generator) hasAdjacentClass(runes []rune) bool {
+	for i := 1; i < len(runes); i++ {
+		if class := g.classOf(runes[i]); class >= 0 && class == g.classOf(runes[i-1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// pickUnderCap возвращает индекс случайного символа из available, набор
+// которого встретился в пароле меньше limit раз (counts - число символов
+// по наборам). Если таких символов нет, выбирается любой символ: кандидат
+// без возможности чередования отбросит checkRules.
+func (g *Generator) pickUnderCap(available []rune, counts []int, limit int) (int, error) {
+	eligible := 0
+	for _, r := range available {
+		if counts[g.classOf(r)] < limit {
+			eligible++
+		}
+	}
+	if eligible == 0 {
+		return g.randomInt(len(available))
+	}
+
+	idx, err := g.randomInt(eligible)
+	if err != nil {
+		return 0, err
+	}
+	for i, r := range available {
+		if counts[g.classOf(r)] >= limit {
+			continue
+		}
+		if idx == 0 {
+			return i, nil
+		}
+		idx--
+	}
+	return 0, nil
+}
+
+// arrangeAlternating переставляет символы пароля так, чтобы соседние
+// символы были из разных наборов. Позиции заполняются с конца случайным
+// символом среди тех, после выбора которых расстановку ещё можно закончить.
+// Если состав пароля не позволяет чередование (символов одного набора
+// больше половины), порядок не меняется - такой кандидат отбросит
+// checkRules. При RequireSymbolAtEnd последний символ остаётся на месте.
+func (g *Generator) arrangeAlternating(password []rune) error {
+	part, prev := password, -1
+	if g.requireSymbolAtEnd && len(password) > 0 {
+		part = password[:len(password)-1]
+		prev = g.classOf(password[len(password)-1])
+	}
+
+	buckets := make([][]rune, len(g.charsets))
+	for _, r := range part {
+		class := g.classOf(r)
+		buckets[class] = append(buckets[class], r)
+	}
+	if !canAlternate(buckets, len(part), prev) {
+		return nil
+	}
+
+	// canPlace проверяет, что после символа из набора class в позиции i
+	// оставшиеся i позиций ещё можно заполнить с чередованием
+	canPlace := func(class, i int) bool {
+		if class == prev || len(buckets[class]) == 0 {
+			return false
+		}
+		buckets[class] = buckets[class][:len(buckets[class])-1]
+		ok := canAlternate(buckets, i, class)
+		buckets[class] = buckets[class][:len(buckets[class])+1]
+		return ok
+	}
+
+	for i := len(part) - 1; i >= 0; i-- {
+		allowed := make([]bool, len(buckets))
+		total := 0
+		for class, bucket := range buckets {
+			if allowed[class] = canPlace(class, i); allowed[class] {
+				total += len(bucket)
+			}
+		}
+
+		idx, err := g.randomInt(total)
+		if err != nil {
+			return err
+		}
+		for class, bucket := range buckets {
+			if !allowed[class] {
+				continue
+			}
+			if idx < len(bucket) {
+				part[i] = bucket[idx]
+				last := len(bucket) - 1
+				bucket[idx], bucket[last] = bucket[last], bucket[idx]
+				buckets[class] = bucket[:last]
+				prev = class
+				break
+			}
+			idx -= len(bucket)
+		}
+	}
+
+	return nil
+}
+
+// canAlternate проверяет, что символы из buckets можно расставить на n
+// позиций без соседних символов из одного набора так, чтобы рядом с уже
+// стоящим символом из набора prev (-1 - такого нет) оказался символ
+// другого набора. Для этого ни один набор не должен занимать больше
+// половины позиций, округлённой вверх, а набор prev - округлённой вниз.
+func canAlternate(buckets [][]rune, n int, prev int) bool {
+	for class, bucket := range buckets {
+		if len(bucket) > (n+1)/2 {
+			return false
+		}
+		if class == prev && len(bucket) > n/2 {
+			return false
+		}
+	}
+	return true
+}
+
+// checkAlternateClasses проверяет, что чередование наборов выполнимо: нужно
+// хотя бы два набора, а без повторов (или с пределом вхождений) каждый набор
+// может занять не больше половины позиций, поэтому длина ограничена
+// суммарной ёмкостью наборов.
+func checkAlternateClasses(charsets [][]rune, length int, allowRepeats bool, maxOccur int) error {
+	if len(charsets) < 2 {
+		return errorf(ErrInvalidConfig, msg(msgAlternateOneSet))
+	}
+	if allowRepeats && maxOccur == 0 {
+		return nil
+	}
+
+	fits := func(length int) bool {
+		capacity := 0
+		for _, group := range charsets {
+			size := len(group)
+			if maxOccur > 0 {
+				size *= maxOccur
+			}
+			capacity += min(size, (length+1)/2)
+		}
+		return capacity >= length
+	}
+
+	if fits(length) {
+		return nil
+	}
+	longest := length - 1
+	for longest > 0 && !fits(longest) {
+		longest--
+	}
+	return errorf(ErrInvalidConfig, msg(msgAlternateLength), length, longest)
+}
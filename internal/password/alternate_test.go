@@ -0,0 +1,128 @@
+package password
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+// runeClass относит символ к классу независимо от генератора
+func runeClass(r rune) string {
+	switch {
+	case unicode.IsDigit(r):
+		return "digit"
+	case unicode.IsLower(r):
+		return "lower"
+	case unicode.IsUpper(r):
+		return "upper"
+	}
+	return "symbol"
+}
+
+func TestAlternateClasses(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{
+			name:   "три набора",
+			config: Config{Length: 16, UseDigits: true, UseLower: true, UseUpper: true, AlternateClasses: true},
+		},
+		{
+			name:   "два набора максимальной длины без повторов",
+			config: Config{Length: 21, UseDigits: true, UseLower: true, AlternateClasses: true},
+		},
+		{
+			name:   "два набора с повторами",
+			config: Config{Length: 30, UseDigits: true, UseUpper: true, AllowRepeats: true, AlternateClasses: true},
+		},
+		{
+			name:   "спецсимвол в конце",
+			config: Config{Length: 12, UseDigits: true, UseLower: true, UseSymbols: true, RequireSymbolAtEnd: true, AlternateClasses: true},
+		},
+		{
+			name:   "диапазон длин",
+			config: Config{MinLength: 8, MaxLength: 14, UseLower: true, UseUpper: true, UseSymbols: true, AlternateClasses: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen, err := NewGenerator(tt.config)
+			if err != nil {
+				t.Fatalf("NewGenerator() failed: %v", err)
+			}
+
+			passwords, err := gen.GenerateUnique(100)
+			if err != nil {
+				t.Fatalf("GenerateUnique() failed: %v", err)
+			}
+			for _, pwd := range passwords {
+				runes := []rune(pwd)
+				for i := 1; i < len(runes); i++ {
+					if runeClass(runes[i]) == runeClass(runes[i-1]) {
+						t.Errorf("password %q has adjacent %s characters at %d", pwd, runeClass(runes[i]), i)
+						break
+					}
+				}
+				if tt.config.RequireSymbolAtEnd && runeClass(runes[len(runes)-1]) != "symbol" {
+					t.Errorf("password %q does not end with a symbol", pwd)
+				}
+				if err := gen.Validate(pwd); err != nil {
+					t.Errorf("Validate(%q) = %v", pwd, err)
+				}
+			}
+		})
+	}
+}
+
+func TestAlternateClassesValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr string
+	}{
+		{
+			name:    "один набор",
+			config:  Config{Length: 8, UseLower: true, AlternateClasses: true},
+			wantErr: "хотя бы два набора",
+		},
+		{
+			name:    "длина больше ёмкости без повторов",
+			config:  Config{Length: 22, UseDigits: true, UseLower: true, AlternateClasses: true},
+			wantErr: "максимум 21",
+		},
+		{
+			name:    "длина больше ёмкости с пределом вхождений",
+			config:  Config{Length: 42, UseDigits: true, UseLower: true, MaxCharOccurrences: 2, AlternateClasses: true},
+			wantErr: "максимум 41",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewGenerator(tt.config)
+			if !errors.Is(err, ErrInvalidConfig) {
+				t.Fatalf("NewGenerator() error = %v, want ErrInvalidConfig", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error %q should contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAlternateClassesValidate(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 4, UseDigits: true, UseLower: true, AlternateClasses: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	if err := gen.Validate("a1b2"); err != nil {
+		t.Errorf("Validate(a1b2) = %v, want nil", err)
+	}
+	if err := gen.Validate("ab12"); err == nil {
+		t.Error("Validate(ab12) = nil, want error for adjacent letters")
+	}
+}
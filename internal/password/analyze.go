@@ -0,0 +1,46 @@
+package password
+
+import "strings"
+
+// Composition содержит количество символов каждого класса в пароле
+type Composition struct {
+	Digits  int
+	Lower   int
+	Upper   int
+	Symbols int
+	Other   int // символы вне стандартных наборов, например из CustomChars
+}
+
+// Analyze подсчитывает состав пароля по тем же наборам символов,
+// что используются при генерации
+func Analyze(password string) Composition {
+	var c Composition
+
+	for _, char := range password {
+		switch {
+		case strings.ContainsRune(digits, char):
+			c.Digits++
+		case strings.ContainsRune(lower, char):
+			c.Lower++
+		case strings.ContainsRune(upper, char):
+			c.Upper++
+		case strings.ContainsRune(symbols, char):
+			c.Symbols++
+		default:
+			c.Other++
+		}
+	}
+
+	return c
+}
+
+// Classes возвращает количество представленных в пароле классов символов
+func (c Composition) Classes() int {
+	count := 0
+	for _, n := range []int{c.Digits, c.Lower, c.Upper, c.Symbols, c.Other} {
+		if n > 0 {
+			count++
+		}
+	}
+	return count
+}
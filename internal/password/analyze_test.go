@@ -0,0 +1,43 @@
+package password
+
+import "testing"
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		want     Composition
+	}{
+		{name: "пустой пароль", password: "", want: Composition{}},
+		{name: "только цифры", password: "123456", want: Composition{Digits: 6}},
+		{name: "смешанный", password: "aB3!xY9#", want: Composition{Digits: 2, Lower: 2, Upper: 2, Symbols: 2}},
+		{name: "с кириллицей", password: "пароль1A", want: Composition{Digits: 1, Upper: 1, Other: 6}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Analyze(tt.password); got != tt.want {
+				t.Errorf("Analyze(%q) = %+v, want %+v", tt.password, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompositionClasses(t *testing.T) {
+	tests := []struct {
+		password string
+		want     int
+	}{
+		{password: "", want: 0},
+		{password: "abc", want: 1},
+		{password: "aB3", want: 3},
+		{password: "aB3!", want: 4},
+		{password: "aB3!ж", want: 5},
+	}
+
+	for _, tt := range tests {
+		if got := Analyze(tt.password).Classes(); got != tt.want {
+			t.Errorf("Analyze(%q).Classes() = %d, want %d", tt.password, got, tt.want)
+		}
+	}
+}
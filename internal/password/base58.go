@@ -0,0 +1,15 @@
+package password
+
+// base58Alphabet - алфавит Base58 (Bitcoin): цифры и латинские буквы без
+// 0, O, I и l, которые легко спутать при переписывании
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// NewBase58Generator создаёт генератор идентификаторов длины length в
+// алфавите Base58. Алфавит считается одним набором, повторы разрешены.
+func NewBase58Generator(length int) (*Generator, error) {
+	return NewGenerator(Config{
+		Length:       length,
+		CustomChars:  base58Alphabet,
+		AllowRepeats: true,
+	})
+}
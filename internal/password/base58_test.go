@@ -0,0 +1,45 @@
+package password
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewBase58Generator(t *testing.T) {
+	if len(base58Alphabet) != 58 {
+		t.Fatalf("base58Alphabet has %d characters, want 58", len(base58Alphabet))
+	}
+
+	// Длина больше алфавита допустима, так как повторы разрешены
+	for _, length := range []int{1, 22, 58, 100} {
+		gen, err := NewBase58Generator(length)
+		if err != nil {
+			t.Fatalf("NewBase58Generator(%d) failed: %v", length, err)
+		}
+
+		tokens, err := gen.GenerateUnique(50)
+		if err != nil {
+			t.Fatalf("GenerateUnique() failed: %v", err)
+		}
+
+		for _, token := range tokens {
+			if len(token) != length {
+				t.Errorf("Token %q has length %d, want %d", token, len(token), length)
+			}
+			for _, r := range token {
+				if !strings.ContainsRune(base58Alphabet, r) {
+					t.Errorf("Token %q contains %q outside base58 alphabet", token, r)
+				}
+			}
+			if strings.ContainsAny(token, "0OIl") {
+				t.Errorf("Token %q contains an ambiguous character", token)
+			}
+		}
+	}
+}
+
+func TestNewBase58GeneratorInvalid(t *testing.T) {
+	if _, err := NewBase58Generator(0); err == nil {
+		t.Error("Expected error for zero length, got none")
+	}
+}
@@ -0,0 +1,26 @@
+package password
+
+import "fmt"
+
+// GenerateUniqueBestEffort генерирует до count уникальных паролей, как
+// GenerateUnique, но при нехватке комбинаций или исчерпании попыток не
+// отбрасывает уже созданные пароли: возвращает их вместе с ошибкой о
+// недостаче. Ошибка оборачивает причину остановки, например
+// ErrCharsetExhausted. Число возможных паролей заранее не проверяется.
+func (g *Generator) GenerateUniqueBestEffort(count int) ([]string, error) {
+	if count <= 0 {
+		return nil, errorf(ErrInvalidCount, msg(msgCountNotPositive))
+	}
+
+	result := make([]string, 0, count)
+	for len(result) < count {
+		password, err := g.Generate()
+		if err != nil {
+			return result, fmt.Errorf(msg(msgBestEffortShortfall), len(result), count, err)
+		}
+		result = append(result, password)
+		g.reportProgress(len(result), count)
+	}
+
+	return result, nil
+}
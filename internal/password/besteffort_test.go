@@ -0,0 +1,49 @@
+package password
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGenerateUniqueBestEffortShortfall(t *testing.T) {
+	// 2 цифры без повторов: всего 90 паролей, запрошено 100
+	gen, err := NewGenerator(Config{Length: 2, UseDigits: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	passwords, err := gen.GenerateUniqueBestEffort(100)
+	if !errors.Is(err, ErrCharsetExhausted) {
+		t.Fatalf("GenerateUniqueBestEffort() error = %v, want ErrCharsetExhausted", err)
+	}
+	if len(passwords) == 0 || len(passwords) > 90 {
+		t.Fatalf("GenerateUniqueBestEffort() returned %d passwords, want 1..90", len(passwords))
+	}
+
+	seen := make(map[string]bool)
+	for _, pwd := range passwords {
+		if seen[pwd] {
+			t.Errorf("Duplicate password %q in partial result", pwd)
+		}
+		seen[pwd] = true
+	}
+}
+
+func TestGenerateUniqueBestEffort(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 10, UseLower: true, UseDigits: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	passwords, err := gen.GenerateUniqueBestEffort(20)
+	if err != nil {
+		t.Fatalf("GenerateUniqueBestEffort() failed: %v", err)
+	}
+	if len(passwords) != 20 {
+		t.Errorf("GenerateUniqueBestEffort() returned %d passwords, want 20", len(passwords))
+	}
+
+	if _, err := gen.GenerateUniqueBestEffort(0); !errors.Is(err, ErrInvalidCount) {
+		t.Errorf("GenerateUniqueBestEffort(0) error = %v, want ErrInvalidCount", err)
+	}
+}
@@ -0,0 +1,39 @@
+package password
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CompareConfigs генерирует по одному паролю длины length для каждой
+// конфигурации, чтобы сравнить варианты наборов символов рядом. Длина из
+// конфигураций заменяется на length. Ключ результата - имена включённых
+// наборов через "+", например "digits+lower+upper"; при совпадении имён
+// к ключу добавляется номер конфигурации: "digits (3)".
+func CompareConfigs(length int, configs []Config) (map[string]string, error) {
+	result := make(map[string]string, len(configs))
+
+	for i, config := range configs {
+		config.Length = length
+		config.MinLength = 0
+		config.MaxLength = 0
+
+		gen, err := NewGenerator(config)
+		if err != nil {
+			return nil, fmt.Errorf(msg(msgCompareConfigFailed), i+1, err)
+		}
+		password, err := gen.Generate()
+		if err != nil {
+			return nil, fmt.Errorf(msg(msgCompareConfigFailed), i+1, err)
+		}
+
+		_, _, names := buildCharset(config)
+		key := strings.Join(names, "+")
+		if _, exists := result[key]; exists {
+			key = fmt.Sprintf("%s (%d)", key, i+1)
+		}
+		result[key] = password
+	}
+
+	return result, nil
+}
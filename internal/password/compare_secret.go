@@ -0,0 +1,10 @@
+package password
+
+import "crypto/subtle"
+
+// SecureCompare сравнивает пароли за время, не зависящее от позиции первого
+// различия, в отличие от ==. Используйте для проверки введённого
+// пользователем пароля. Длина строк при этом не скрывается.
+func SecureCompare(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
@@ -0,0 +1,31 @@
+package password
+
+import "testing"
+
+func TestSecureCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want bool
+	}{
+		{name: "равные", a: "Xk9#mPq2", b: "Xk9#mPq2", want: true},
+		{name: "пустые", a: "", b: "", want: true},
+		{name: "unicode", a: "пароль🔑", b: "пароль🔑", want: true},
+		{name: "различие в последнем символе", a: "Xk9#mPq2", b: "Xk9#mPq3", want: false},
+		{name: "различие в первом символе", a: "Xk9#mPq2", b: "xk9#mPq2", want: false},
+		{name: "префикс", a: "Xk9#mPq2", b: "Xk9#", want: false},
+		{name: "разная длина", a: "abc", b: "abcd", want: false},
+		{name: "пустая и непустая", a: "", b: "a", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SecureCompare(tt.a, tt.b); got != tt.want {
+				t.Errorf("SecureCompare(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+			if got := SecureCompare(tt.b, tt.a); got != tt.want {
+				t.Errorf("SecureCompare(%q, %q) = %v, want %v", tt.b, tt.a, got, tt.want)
+			}
+		})
+	}
+}
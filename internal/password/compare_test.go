@@ -0,0 +1,59 @@
+package password
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestCompareConfigs(t *testing.T) {
+	configs := []Config{
+		{UseDigits: true},
+		{UseDigits: true, UseLower: true, UseUpper: true},
+		{UseDigits: true, UseLower: true, UseUpper: true, UseSymbols: true},
+		{Length: 4, UseDigits: true, ExcludeChars: "01"},
+	}
+	charsets := map[string]string{
+		"digits":                     digits,
+		"digits+lower+upper":         digits + lower + upper,
+		"digits+lower+upper+symbols": digits + lower + upper + symbols,
+		"digits (4)":                 "23456789",
+	}
+
+	result, err := CompareConfigs(8, configs)
+	if err != nil {
+		t.Fatalf("CompareConfigs() failed: %v", err)
+	}
+
+	if len(result) != len(configs) {
+		t.Errorf("CompareConfigs() returned %d entries, want %d: %v", len(result), len(configs), result)
+	}
+
+	for key, charset := range charsets {
+		password, ok := result[key]
+		if !ok {
+			t.Errorf("CompareConfigs() has no entry %q: %v", key, result)
+			continue
+		}
+		if utf8.RuneCountInString(password) != 8 {
+			t.Errorf("%s: password %q has length %d, want 8", key, password, utf8.RuneCountInString(password))
+		}
+		for _, r := range password {
+			if !strings.ContainsRune(charset, r) {
+				t.Errorf("%s: password %q contains %q outside the charset", key, password, r)
+			}
+		}
+	}
+}
+
+func TestCompareConfigsInvalid(t *testing.T) {
+	// Длина 11 невозможна для цифр без повторов
+	_, err := CompareConfigs(11, []Config{{UseLower: true}, {UseDigits: true}})
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("CompareConfigs() error = %v, want ErrInvalidConfig", err)
+	}
+	if err != nil && !strings.Contains(err.Error(), "конфигурация 2") {
+		t.Errorf("CompareConfigs() error = %v, want mention of configuration 2", err)
+	}
+}
@@ -0,0 +1,45 @@
+package password
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// defaultFileLength - длина пароля, если в файле конфигурации не задана ни одна длина
+const defaultFileLength = 16
+
+// DefaultConfig возвращает конфигурацию по умолчанию, используемую для
+// ключей, отсутствующих в файле: цифры и буквы обоих регистров
+func DefaultConfig() Config {
+	return Config{
+		UseDigits: true,
+		UseLower:  true,
+		UseUpper:  true,
+	}
+}
+
+// LoadConfig читает конфигурацию из JSON-файла. Отсутствующие ключи
+// берутся из DefaultConfig, а если не задана ни length, ни min_length/max_length,
+// используется длина 16. Неизвестные ключи считаются ошибкой.
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf(msg(msgConfigReadFailed), err)
+	}
+
+	config := DefaultConfig()
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		return Config{}, fmt.Errorf(msg(msgConfigInvalid), path, err)
+	}
+
+	if config.Length == 0 && config.MinLength == 0 && config.MaxLength == 0 {
+		config.Length = defaultFileLength
+	}
+
+	return config, nil
+}
@@ -0,0 +1,97 @@
+package password
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeConfigFile создаёт временный файл конфигурации с заданным содержимым
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := writeConfigFile(t, `{
+		"length": 20,
+		"use_digits": false,
+		"use_symbols": true,
+		"exclude_chars": "lI0O",
+		"max_consecutive": 2,
+		"min_entropy_bits": 60
+	}`)
+
+	config, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() failed: %v", err)
+	}
+
+	want := Config{
+		Length:         20,
+		UseLower:       true,
+		UseUpper:       true,
+		UseSymbols:     true,
+		ExcludeChars:   "lI0O",
+		MaxConsecutive: 2,
+		MinEntropyBits: 60,
+	}
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("LoadConfig() = %+v, want %+v", config, want)
+	}
+
+	if _, err := NewGenerator(config); err != nil {
+		t.Errorf("NewGenerator() failed for loaded config: %v", err)
+	}
+}
+
+func TestLoadConfigDefaults(t *testing.T) {
+	config, err := LoadConfig(writeConfigFile(t, `{}`))
+	if err != nil {
+		t.Fatalf("LoadConfig() failed: %v", err)
+	}
+
+	want := DefaultConfig()
+	want.Length = defaultFileLength
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("LoadConfig() = %+v, want %+v", config, want)
+	}
+
+	// Диапазон длин не должен подменяться длиной по умолчанию
+	config, err = LoadConfig(writeConfigFile(t, `{"min_length": 8, "max_length": 12}`))
+	if err != nil {
+		t.Fatalf("LoadConfig() failed: %v", err)
+	}
+	if config.Length != 0 || config.MinLength != 8 || config.MaxLength != 12 {
+		t.Errorf("LoadConfig() lengths = %d/%d/%d, want 0/8/12", config.Length, config.MinLength, config.MaxLength)
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "некорректный JSON", content: `{"length": 12,`},
+		{name: "неверный тип", content: `{"length": "twelve"}`},
+		{name: "неизвестный ключ", content: `{"lenght": 12}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadConfig(writeConfigFile(t, tt.content)); err == nil {
+				t.Error("Expected error, got none")
+			}
+		})
+	}
+
+	if _, err := LoadConfig(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Expected error for missing file, got none")
+	}
+}
@@ -0,0 +1,33 @@
+package password
+
+import (
+	"context"
+	"fmt"
+)
+
+// GenerateUniqueContext генерирует count уникальных паролей, как GenerateUnique,
+// но прекращает работу при отмене ctx или истечении его срока. Контекст
+// проверяется перед каждым паролем, поэтому задержка прерывания не больше
+// времени генерации одного пароля (ограничено Config.MaxAttempts).
+// Ошибка прерывания оборачивает ctx.Err(): errors.Is(err, context.DeadlineExceeded).
+func (g *Generator) GenerateUniqueContext(ctx context.Context, count int) ([]string, error) {
+	if err := g.checkUniqueCount(count); err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for i := 0; i < count; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf(msg(msgGenerateInterrupted), i, count, err)
+		}
+
+		password, err := g.Generate()
+		if err != nil {
+			return nil, fmt.Errorf(msg(msgGenerateUniqueFailed), count, err)
+		}
+		result = append(result, password)
+		g.reportProgress(len(result), count)
+	}
+
+	return result, nil
+}
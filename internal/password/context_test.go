@@ -0,0 +1,52 @@
+package password
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGenerateUniqueContextDeadline(t *testing.T) {
+	// 6 цифр без повторов: 151200 паролей, почти все запрошены - генерация
+	// занимает намного больше срока контекста
+	gen, err := NewGenerator(Config{Length: 6, UseDigits: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	passwords, err := gen.GenerateUniqueContext(ctx, 150000)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("GenerateUniqueContext() error = %v, want context.DeadlineExceeded", err)
+	}
+	if passwords != nil {
+		t.Errorf("GenerateUniqueContext() returned %d passwords on timeout, want nil", len(passwords))
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("GenerateUniqueContext() took %v after a 20ms deadline", elapsed)
+	}
+}
+
+func TestGenerateUniqueContextCanceled(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 8, UseLower: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := gen.GenerateUniqueContext(ctx, 5); !errors.Is(err, context.Canceled) {
+		t.Errorf("GenerateUniqueContext() error = %v, want context.Canceled", err)
+	}
+
+	// Без отмены работает как GenerateUnique
+	passwords, err := gen.GenerateUniqueContext(context.Background(), 5)
+	if err != nil || len(passwords) != 5 {
+		t.Errorf("GenerateUniqueContext() = %v, %v, want 5 passwords", passwords, err)
+	}
+}
@@ -0,0 +1,97 @@
+package password
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// DefaultGuessesPerSecond - скорость перебора по умолчанию: офлайн-атака
+// на быстрый хеш с помощью нескольких GPU
+const DefaultGuessesPerSecond = 1e10
+
+// crackSeconds возвращает среднее время подбора в секундах: в среднем
+// перебирается половина пространства, то есть 2^(bits-1) вариантов
+func crackSeconds(entropyBits, guessesPerSecond float64) float64 {
+	if guessesPerSecond <= 0 {
+		guessesPerSecond = DefaultGuessesPerSecond
+	}
+	if entropyBits <= 0 {
+		return 0
+	}
+	return math.Exp2(entropyBits-1) / guessesPerSecond
+}
+
+// TimeToCrack оценивает среднее время подбора пароля с энтропией entropyBits
+// при скорости guessesPerSecond попыток в секунду (неположительное значение -
+// DefaultGuessesPerSecond). Результат ограничен максимальным time.Duration
+// (около 292 лет); для больших значений используйте CrackTimeString.
+func TimeToCrack(entropyBits, guessesPerSecond float64) time.Duration {
+	seconds := crackSeconds(entropyBits, guessesPerSecond)
+	if seconds >= float64(math.MaxInt64)/float64(time.Second) {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// crackUnits - единицы для CrackTimeString от крупных к мелким. Сообщение
+// единицы содержит через "|" формы для 1, 2-4 и 5+ (в английском - 1 и 2+).
+var crackUnits = []struct {
+	seconds float64
+	forms   messageID
+}{
+	{365.25 * 24 * 3600, msgCrackYears},
+	{24 * 3600, msgCrackDays},
+	{3600, msgCrackHours},
+	{60, msgCrackMinutes},
+	{1, msgCrackSeconds},
+}
+
+// CrackTimeString возвращает время подбора в виде, удобном для отображения,
+// например "3 года" или "2e+15 лет". Язык выбирается по Language.
+func CrackTimeString(entropyBits, guessesPerSecond float64) string {
+	seconds := crackSeconds(entropyBits, guessesPerSecond)
+	if seconds < 1 {
+		return msg(msgCrackUnderSecond)
+	}
+
+	for _, unit := range crackUnits {
+		if seconds < unit.seconds {
+			continue
+		}
+		var forms [3]string
+		copy(forms[:], strings.Split(msg(unit.forms), "|"))
+		value := seconds / unit.seconds
+		if value >= 1e6 {
+			return fmt.Sprintf("%.0e %s", value, forms[2])
+		}
+		n := int64(value)
+		return fmt.Sprintf("%d %s", n, pluralForm(n, forms))
+	}
+	return msg(msgCrackUnderSecond)
+}
+
+// pluralForm выбирает форму существительного для числа n по правилам
+// языка Language: в английском - единственное число только для 1, в
+// русском (и по умолчанию, как в msg) - три формы
+func pluralForm(n int64, forms [3]string) string {
+	if Language == English {
+		if n == 1 {
+			return forms[0]
+		}
+		return forms[2]
+	}
+
+	n %= 100
+	if n >= 11 && n <= 14 {
+		return forms[2]
+	}
+	switch n % 10 {
+	case 1:
+		return forms[0]
+	case 2, 3, 4:
+		return forms[1]
+	}
+	return forms[2]
+}
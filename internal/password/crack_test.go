@@ -0,0 +1,94 @@
+package password
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTimeToCrack(t *testing.T) {
+	tests := []struct {
+		name  string
+		bits  float64
+		rate  float64
+		want  time.Duration
+		delta time.Duration
+	}{
+		// 2^20 / 2 / 1024 = 512 секунд
+		{name: "20 бит при 1024/с", bits: 20, rate: 1024, want: 512 * time.Second, delta: time.Millisecond},
+		// 2^40 / 2 / 1e10 ≈ 55 секунд
+		{name: "40 бит по умолчанию", bits: 40, rate: 0, want: 55 * time.Second, delta: time.Second},
+		// 2^60 / 2 / 1e10 ≈ 1.8 года
+		{name: "60 бит по умолчанию", bits: 60, rate: 0, want: 16013 * time.Hour, delta: time.Hour},
+		{name: "нулевая энтропия", bits: 0, rate: 1e6, want: 0, delta: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TimeToCrack(tt.bits, tt.rate)
+			if diff := got - tt.want; diff < -tt.delta || diff > tt.delta {
+				t.Errorf("TimeToCrack(%v, %v) = %v, want %v ± %v", tt.bits, tt.rate, got, tt.want, tt.delta)
+			}
+		})
+	}
+
+	if got := TimeToCrack(128, 1e12); got != time.Duration(math.MaxInt64) {
+		t.Errorf("TimeToCrack(128, 1e12) = %v, want saturated max duration", got)
+	}
+}
+
+func TestCrackTimeString(t *testing.T) {
+	useLanguage(t, Russian)
+
+	tests := []struct {
+		bits float64
+		rate float64
+		want string
+	}{
+		{bits: 10, rate: 1e6, want: "меньше секунды"},
+		{bits: 20, rate: 1024, want: "8 минут"},
+		{bits: 30, rate: 1, want: "17 лет"},
+		{bits: 26, rate: 1, want: "1 год"},
+		{bits: 25, rate: 1, want: "194 дня"},
+		{bits: 128, rate: 1e10, want: "5e+20 лет"},
+	}
+
+	for _, tt := range tests {
+		if got := CrackTimeString(tt.bits, tt.rate); got != tt.want {
+			t.Errorf("CrackTimeString(%v, %v) = %q, want %q", tt.bits, tt.rate, got, tt.want)
+		}
+	}
+}
+
+func TestPluralForm(t *testing.T) {
+	useLanguage(t, Russian)
+
+	forms := [3]string{"год", "года", "лет"}
+	for n, want := range map[int64]string{1: "год", 2: "года", 5: "лет", 11: "лет", 21: "год", 112: "лет", 104: "года"} {
+		if got := pluralForm(n, forms); got != want {
+			t.Errorf("pluralForm(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestCrackTimeStringEnglish(t *testing.T) {
+	useLanguage(t, English)
+
+	tests := []struct {
+		bits float64
+		rate float64
+		want string
+	}{
+		{bits: 10, rate: 1e6, want: "less than a second"},
+		{bits: 20, rate: 1024, want: "8 minutes"},
+		{bits: 26, rate: 1, want: "1 year"},
+		{bits: 25, rate: 1, want: "194 days"},
+		{bits: 128, rate: 1e10, want: "5e+20 years"},
+	}
+
+	for _, tt := range tests {
+		if got := CrackTimeString(tt.bits, tt.rate); got != tt.want {
+			t.Errorf("CrackTimeString(%v, %v) = %q, want %q", tt.bits, tt.rate, got, tt.want)
+		}
+	}
+}
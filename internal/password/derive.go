@@ -0,0 +1,62 @@
+package password
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// Параметры scrypt для DeriveFromMaster: N=2^15, r=8, p=1 - рекомендованные
+// значения для интерактивного входа (около 32 МБ памяти на вывод)
+const (
+	deriveCostN   = 1 << 15
+	deriveCostR   = 8
+	deriveCostP   = 1
+	deriveKeySize = 32
+)
+
+// deriveSalt отделяет соль DeriveFromMaster от других применений scrypt
+// с тем же мастер-паролем
+const deriveSalt = "passwordgen/derive/v1:"
+
+// zeroReader бесконечно отдаёт нулевые байты
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+// DeriveFromMaster детерминированно выводит пароль для сайта site из
+// мастер-пароля master: одинаковые master, site и config всегда дают один и
+// тот же пароль, а разные сайты - независимые пароли. Ключ получается через
+// scrypt с солью из имени сайта, а его поток AES-CTR служит источником
+// случайности для обычной генерации по config.
+//
+// Config.Store не используется: пароль, найденный в хранилище, пришлось бы
+// перегенерировать, и результат перестал бы воспроизводиться.
+func DeriveFromMaster(master, site string, config Config) (string, error) {
+	if master == "" {
+		return "", errorf(ErrInvalidConfig, msg(msgMasterEmpty))
+	}
+
+	key, err := scrypt.Key([]byte(master), []byte(deriveSalt+site), deriveCostN, deriveCostR, deriveCostP, deriveKeySize)
+	if err != nil {
+		return "", err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+	iv := make([]byte, aes.BlockSize)
+	stream := cipher.StreamReader{S: cipher.NewCTR(block, iv), R: zeroReader{}}
+
+	config.Store = nil
+	gen, err := NewGeneratorWithReader(config, stream)
+	if err != nil {
+		return "", err
+	}
+	return gen.Generate()
+}
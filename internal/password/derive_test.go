@@ -0,0 +1,59 @@
+package password
+
+import (
+	"errors"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestDeriveFromMaster(t *testing.T) {
+	config := Config{Length: 16, UseDigits: true, UseLower: true, UseUpper: true, UseSymbols: true}
+
+	first, err := DeriveFromMaster("correct horse", "example.com", config)
+	if err != nil {
+		t.Fatalf("DeriveFromMaster() failed: %v", err)
+	}
+	if utf8.RuneCountInString(first) != 16 {
+		t.Errorf("Password %q has length %d, want 16", first, utf8.RuneCountInString(first))
+	}
+	gen, err := NewGenerator(config)
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+	if err := gen.Validate(first); err != nil {
+		t.Errorf("Validate(%q) failed: %v", first, err)
+	}
+
+	// Те же входные данные - тот же пароль
+	again, err := DeriveFromMaster("correct horse", "example.com", config)
+	if err != nil {
+		t.Fatalf("DeriveFromMaster() failed: %v", err)
+	}
+	if again != first {
+		t.Errorf("DeriveFromMaster() = %q on second call, want %q", again, first)
+	}
+
+	// Другой сайт или мастер-пароль - другой пароль
+	for _, tt := range []struct{ master, site string }{
+		{"correct horse", "example.org"},
+		{"correct horse", "Example.com"},
+		{"correct horse battery", "example.com"},
+	} {
+		other, err := DeriveFromMaster(tt.master, tt.site, config)
+		if err != nil {
+			t.Fatalf("DeriveFromMaster(%q, %q) failed: %v", tt.master, tt.site, err)
+		}
+		if other == first {
+			t.Errorf("DeriveFromMaster(%q, %q) = %q, same as for example.com", tt.master, tt.site, other)
+		}
+	}
+}
+
+func TestDeriveFromMasterErrors(t *testing.T) {
+	if _, err := DeriveFromMaster("", "example.com", Config{Length: 8, UseLower: true}); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("DeriveFromMaster() with empty master error = %v, want ErrInvalidConfig", err)
+	}
+	if _, err := DeriveFromMaster("secret", "example.com", Config{Length: 8}); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("DeriveFromMaster() without charsets error = %v, want ErrInvalidConfig", err)
+	}
+}
@@ -0,0 +1,30 @@
+package password
+
+// digitRejectLimit - наибольшее кратное 10 число, не превышающее 256:
+// байты от 250 отбрасываются, чтобы остаток от деления на 10 был равномерным
+const digitRejectLimit = 250
+
+// generateDigits - быстрый путь для набора из 10 цифр с разрешёнными повторами
+// (PIN, OTP). Каждая позиция независима и равномерна, поэтому не нужны ни
+// копия набора, ни обязательные группы, ни перемешивание. Случайные байты
+// читаются пачкой вместо отдельного big.Int на каждую цифру. Используется
+// только с crypto/rand: детерминированные источники идут общим путём.
+func (g *Generator) generateDigits(length int) (string, error) {
+	result := make([]byte, length)
+
+	buf := make([]byte, length)
+	for i := 0; i < length; {
+		if err := readRandom(g.reader(), buf[:length-i]); err != nil {
+			return "", err
+		}
+		for _, b := range buf[:length-i] {
+			if b >= digitRejectLimit {
+				continue
+			}
+			result[i] = '0' + b%10
+			i++
+		}
+	}
+
+	return string(result), nil
+}
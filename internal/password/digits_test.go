@@ -0,0 +1,106 @@
+package password
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateDigitsFastPath(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 8, UseDigits: true, AllowRepeats: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+	if !gen.digitsOnly {
+		t.Fatal("digits-only config with repeats should use the fast path")
+	}
+
+	// Без повторов, с исключениями или с другими наборами быстрый путь не используется
+	for _, config := range []Config{
+		{Length: 8, UseDigits: true},
+		{Length: 8, UseDigits: true, AllowRepeats: true, ExcludeChars: "0"},
+		{Length: 8, UseDigits: true, UseLower: true, AllowRepeats: true},
+	} {
+		other, err := NewGenerator(config)
+		if err != nil {
+			t.Fatalf("NewGenerator() failed: %v", err)
+		}
+		if other.digitsOnly {
+			t.Errorf("config %+v should not use the fast path", config)
+		}
+	}
+
+	const samples = 5000
+	var counts [10]int
+	for i := 0; i < samples; i++ {
+		password, err := gen.generateOne()
+		if err != nil {
+			t.Fatalf("generateOne() failed: %v", err)
+		}
+		if len(password) != 8 {
+			t.Fatalf("Password %q length = %d, want 8", password, len(password))
+		}
+		for _, r := range password {
+			if !strings.ContainsRune(digits, r) {
+				t.Fatalf("Password %q contains non-digit %q", password, r)
+			}
+			counts[r-'0']++
+		}
+	}
+
+	// Хи-квадрат с 9 степенями свободы: 27.88 соответствует p = 0.001
+	expected := float64(samples*8) / 10
+	chi := 0.0
+	for _, c := range counts {
+		d := float64(c) - expected
+		chi += d * d / expected
+	}
+	if chi > 27.88 {
+		t.Errorf("digit distribution is not uniform: chi-square = %.2f, counts = %v", chi, counts)
+	}
+}
+
+// Генератор с зерном не использует быстрый путь и выдаёт те же пароли,
+// что и до его появления
+func TestGenerateDigitsSeededOutput(t *testing.T) {
+	gen, err := NewSeededGenerator(Config{Length: 6, UseDigits: true, AllowRepeats: true}, 42)
+	if err != nil {
+		t.Fatalf("NewSeededGenerator() failed: %v", err)
+	}
+
+	for _, want := range []string{"583570", "825372", "491425"} {
+		got, err := gen.Generate()
+		if err != nil {
+			t.Fatalf("Generate() failed: %v", err)
+		}
+		if got != want {
+			t.Errorf("Generate() = %q, want %q", got, want)
+		}
+	}
+}
+
+func BenchmarkGenerateDigitsFastPath(b *testing.B) {
+	gen, err := NewGenerator(Config{Length: 6, UseDigits: true, AllowRepeats: true})
+	if err != nil {
+		b.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	for i := 0; i < b.N; i++ {
+		if _, err := gen.generateOne(); err != nil {
+			b.Fatalf("generateOne() failed: %v", err)
+		}
+	}
+}
+
+func BenchmarkGenerateDigitsGeneralPath(b *testing.B) {
+	gen, err := NewGenerator(Config{Length: 6, UseDigits: true, AllowRepeats: true})
+	if err != nil {
+		b.Fatalf("NewGenerator() failed: %v", err)
+	}
+	gen.digitsOnly = false
+
+	for i := 0; i < b.N; i++ {
+		if _, err := gen.generateOne(); err != nil {
+			b.Fatalf("generateOne() failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+package password
+
+// emoji - набор эмодзи для Config.UseEmoji. Каждый эмодзи - одна кодовая
+// точка с эмодзи-представлением по умолчанию: без вариантных селекторов
+// (U+FE0F), модификаторов тона кожи и соединителей (U+200D). Поэтому одна
+// руна всегда отображается как один символ, и длина пароля в рунах совпадает
+// с видимой длиной в графемах. Составные эмодзи сюда добавлять нельзя.
+const emoji = "😀😁😂😃😄😅😆😉😊😋😎😍🙂🤔🤗🤩" +
+	"🐶🐱🐭🐹🐰🦊🐻🐼🐨🐯🦁🐮🐷🐸🐵🐔🐧🐤🦄🐝🐛🦋🐌🐞🐢🐙🐬🐳🐟" +
+	"🌵🌲🌴🍀🍁🍄🌸🌻🌞🌙⭐🔥🌈⛄" +
+	"🍎🍐🍊🍋🍌🍉🍇🍓🍒🍑🍍🥝🍕🍔🍟🌭🍩🍪🎂🍫🍿" +
+	"⚽🏀🏈⚾🎾🏐🎱🎯🎲🎸🎺🎻🚀🚗🚲⛵🎈🎁🔑💎🔔📚✅"
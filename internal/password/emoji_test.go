@@ -0,0 +1,75 @@
+package password
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestEmojiSetSingleRune(t *testing.T) {
+	seen := make(map[rune]bool)
+	for _, r := range emoji {
+		// Вариантные селекторы, соединители и модификаторы тона превращают
+		// несколько рун в одну графему
+		if r == 0xFE0F || r == 0x200D || (r >= 0x1F3FB && r <= 0x1F3FF) {
+			t.Errorf("emoji set contains joiner or modifier %U", r)
+		}
+		if r < 0x2000 {
+			t.Errorf("emoji set contains non-emoji rune %U", r)
+		}
+		if seen[r] {
+			t.Errorf("emoji set contains duplicate %q", r)
+		}
+		seen[r] = true
+	}
+}
+
+func TestGenerateWithEmoji(t *testing.T) {
+	const length = 10
+
+	gen, err := NewGeneratorWithOptions(length, WithLower(), WithEmoji())
+	if err != nil {
+		t.Fatalf("NewGeneratorWithOptions() failed: %v", err)
+	}
+
+	passwords, err := gen.GenerateUnique(100)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+
+	for _, password := range passwords {
+		if got := utf8.RuneCountInString(password); got != length {
+			t.Errorf("Password %q displayed length = %d, want %d", password, got, length)
+		}
+		if !strings.ContainsAny(password, emoji) {
+			t.Errorf("Password %q contains no emoji", password)
+		}
+		if !strings.ContainsAny(password, lower) {
+			t.Errorf("Password %q contains no lowercase letter", password)
+		}
+		if err := gen.Validate(password); err != nil {
+			t.Errorf("Validate(%q) = %v", password, err)
+		}
+	}
+}
+
+func TestGenerateEmojiOnly(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 6, UseEmoji: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	password, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	for _, r := range password {
+		if !strings.ContainsRune(emoji, r) {
+			t.Errorf("Password %q contains non-emoji rune %q", password, r)
+		}
+	}
+	if got := utf8.RuneCountInString(password); got != 6 {
+		t.Errorf("Password %q displayed length = %d, want 6", password, got)
+	}
+}
@@ -0,0 +1,39 @@
+package password
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// Режимы кодирования для GenerateEncoded
+const (
+	EncodeHex    = "hex"
+	EncodeBase64 = "base64"
+)
+
+// GenerateEncoded генерирует bytesLen случайных байт через crypto/rand и
+// возвращает их в виде hex или base64 строки. Наборы символов не используются,
+// что удобно для токенов в стиле API-ключей.
+func GenerateEncoded(bytesLen int, mode string) (string, error) {
+	if bytesLen <= 0 {
+		return "", errorf(ErrInvalidConfig, msg(msgBytesNotPositive))
+	}
+
+	var encode func([]byte) string
+	switch mode {
+	case EncodeHex:
+		encode = hex.EncodeToString
+	case EncodeBase64:
+		encode = base64.StdEncoding.EncodeToString
+	default:
+		return "", errorf(ErrInvalidConfig, msg(msgUnknownEncoding), mode)
+	}
+
+	buf := make([]byte, bytesLen)
+	if _, err := rand.Read(buf); err != nil {
+		return "", errorf(ErrRandom, msg(msgRandomBytesFailed), err)
+	}
+
+	return encode(buf), nil
+}
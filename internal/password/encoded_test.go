@@ -0,0 +1,59 @@
+package password
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+)
+
+func TestGenerateEncodedHex(t *testing.T) {
+	for _, n := range []int{1, 16, 32} {
+		token, err := GenerateEncoded(n, EncodeHex)
+		if err != nil {
+			t.Fatalf("GenerateEncoded(%d, hex) failed: %v", n, err)
+		}
+
+		if len(token) != 2*n {
+			t.Errorf("hex token length = %d, want %d", len(token), 2*n)
+		}
+
+		decoded, err := hex.DecodeString(token)
+		if err != nil {
+			t.Errorf("hex token %q does not decode: %v", token, err)
+		}
+		if len(decoded) != n {
+			t.Errorf("decoded length = %d, want %d", len(decoded), n)
+		}
+	}
+}
+
+func TestGenerateEncodedBase64(t *testing.T) {
+	for _, n := range []int{1, 16, 32} {
+		token, err := GenerateEncoded(n, EncodeBase64)
+		if err != nil {
+			t.Fatalf("GenerateEncoded(%d, base64) failed: %v", n, err)
+		}
+
+		if want := base64.StdEncoding.EncodedLen(n); len(token) != want {
+			t.Errorf("base64 token length = %d, want %d", len(token), want)
+		}
+
+		decoded, err := base64.StdEncoding.DecodeString(token)
+		if err != nil {
+			t.Errorf("base64 token %q does not decode: %v", token, err)
+		}
+		if len(decoded) != n {
+			t.Errorf("decoded length = %d, want %d", len(decoded), n)
+		}
+	}
+}
+
+func TestGenerateEncodedErrors(t *testing.T) {
+	if _, err := GenerateEncoded(0, EncodeHex); err == nil {
+		t.Error("Expected error for zero bytes, got none")
+	}
+
+	if _, err := GenerateEncoded(16, "base32"); err == nil {
+		t.Error("Expected error for unknown mode, got none")
+	}
+}
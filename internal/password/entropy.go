@@ -0,0 +1,115 @@
+package password
+
+import (
+	"math"
+	"math/big"
+)
+
+// Entropy возвращает энтропию конфигурации генератора в битах.
+// Для диапазона длин берётся минимальная длина как наихудший случай.
+func (g *Generator) Entropy() float64 {
+	length := g.length
+	if length == 0 {
+		length = g.minLength
+	}
+	return entropyBits(len(g.charset), length, g.allowRepeats)
+}
+
+// entropyBits вычисляет энтропию пароля длины length из n символов:
+// length*log2(n) при повторах или log2(n!/(n-length)!) без них
+func entropyBits(n, length int, allowRepeats bool) float64 {
+	if n <= 0 || length <= 0 {
+		return 0
+	}
+
+	if allowRepeats {
+		return float64(length) * math.Log2(float64(n))
+	}
+
+	if length > n {
+		return 0
+	}
+
+	bits := 0.0
+	for i := 0; i < length; i++ {
+		bits += math.Log2(float64(n - i))
+	}
+	return bits
+}
+
+// minLengthForEntropy возвращает минимальную длину, при которой энтропия
+// достигает bits, или 0, если это невозможно для данного набора символов
+func minLengthForEntropy(n int, bits float64, allowRepeats bool) int {
+	if n <= 1 {
+		return 0
+	}
+
+	maxLength := n
+	if allowRepeats {
+		maxLength = int(math.Ceil(bits/math.Log2(float64(n)))) + 1
+	}
+
+	for length := 1; length <= maxLength; length++ {
+		if entropyBits(n, length, allowRepeats) >= bits {
+			return length
+		}
+	}
+	return 0
+}
+
+// LengthForEntropy возвращает минимальную длину пароля, при которой
+// конфигурация (наборы символов, исключения, повторы) даёт не меньше bits
+// бит энтропии. Поля длины в config игнорируются. Возвращает 0, если цель
+// недостижима: набор пуст или без повторов в нём слишком мало символов.
+func LengthForEntropy(config Config, bits float64) int {
+	charset, _, _ := buildCharset(config)
+	return minLengthForEntropy(len(charset), bits, config.AllowRepeats)
+}
+
+// ConfigForEntropy подбирает длину для задачи вида "count паролей, каждый
+// не слабее bits бит": минимальную длину, при которой конфигурация даёт
+// не меньше bits бит энтропии и не меньше count уникальных паролей. Если
+// длина в config уже задана (Length или MinLength для диапазона), она
+// только проверяется, иначе в возвращаемую конфигурацию записывается Length.
+// Недостижимая энтропия или слишком короткая заданная длина - ErrInvalidConfig,
+// недостижимое количество - ErrCharsetExhausted.
+func ConfigForEntropy(config Config, bits float64, count int) (Config, error) {
+	if count <= 0 {
+		return config, errorf(ErrInvalidCount, msg(msgCountNotPositive))
+	}
+
+	charset, _, _ := buildCharset(config)
+	n := len(charset)
+
+	length := minLengthForEntropy(n, bits, config.AllowRepeats)
+	if length == 0 {
+		return config, errorf(ErrInvalidConfig, msg(msgEntropyTooLowSets), entropyBits(n, n, false), bits)
+	}
+
+	// Удлиняем пароль, пока комбинаций не хватит на count паролей
+	for countPasswords(n, length, config.AllowRepeats).Cmp(big.NewInt(int64(count))) < 0 {
+		if n <= 1 || (!config.AllowRepeats && length >= n) {
+			return config, errorf(ErrCharsetExhausted, msg(msgCountExceedsMaxUnique), count, countPasswords(n, length, config.AllowRepeats))
+		}
+		length++
+	}
+
+	configured := config.Length
+	if configured == 0 {
+		configured = config.MinLength
+	}
+	if configured == 0 && config.MaxLength == 0 {
+		config.Length = length
+		return config, nil
+	}
+	if configured < length {
+		return config, errorf(ErrInvalidConfig, msg(msgLengthBelowEntropyCount), configured, bits, count, length)
+	}
+	return config, nil
+}
+
+// EntropyForLength возвращает энтропию пароля длины length для набора
+// символов генератора, например для паролей из диапазона длин
+func (g *Generator) EntropyForLength(length int) float64 {
+	return entropyBits(len(g.charset), length, g.allowRepeats)
+}
@@ -0,0 +1,222 @@
+package password
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestEntropyBits(t *testing.T) {
+	tests := []struct {
+		name         string
+		n            int
+		length       int
+		allowRepeats bool
+		want         float64
+	}{
+		{name: "digits длина 3 без повторов", n: 10, length: 3, want: math.Log2(720)},
+		{name: "digits длина 3 с повторами", n: 10, length: 3, allowRepeats: true, want: math.Log2(1000)},
+		{name: "длина больше набора", n: 10, length: 11, want: 0},
+		{name: "пустой набор", n: 0, length: 5, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := entropyBits(tt.n, tt.length, tt.allowRepeats)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("entropyBits(%d, %d, %v) = %f, want %f", tt.n, tt.length, tt.allowRepeats, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMinEntropyBits(t *testing.T) {
+	weak := Config{Length: 4, UseDigits: true, MinEntropyBits: 40}
+	_, err := NewGenerator(weak)
+	if err == nil {
+		t.Fatal("Expected error for weak config, got none")
+	}
+	if !strings.Contains(err.Error(), "40.0") {
+		t.Errorf("Error %q should mention required entropy", err)
+	}
+
+	strong := Config{Length: 16, UseDigits: true, UseLower: true, UseUpper: true, UseSymbols: true, MinEntropyBits: 40}
+	gen, err := NewGenerator(strong)
+	if err != nil {
+		t.Fatalf("NewGenerator() failed for strong config: %v", err)
+	}
+	if gen.Entropy() < 40 {
+		t.Errorf("Entropy() = %f, want >= 40", gen.Entropy())
+	}
+}
+
+func TestMinEntropyBitsSuggestsLength(t *testing.T) {
+	// 36 символов без повторов: 8 символов дают ~41 бит, 7 - ~36 бит
+	_, err := NewGenerator(Config{Length: 6, UseDigits: true, UseLower: true, MinEntropyBits: 40})
+	if err == nil {
+		t.Fatal("Expected error for weak config, got none")
+	}
+	if !strings.Contains(err.Error(), "до 8 символов") {
+		t.Errorf("Error %q should suggest length 8", err)
+	}
+
+	// Даже все 10 цифр не дают 40 бит
+	_, err = NewGenerator(Config{Length: 4, UseDigits: true, MinEntropyBits: 40})
+	if err == nil || !strings.Contains(err.Error(), "наборов символов") {
+		t.Errorf("Error %v should suggest enabling more character sets", err)
+	}
+}
+
+func TestLengthForEntropy(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		bits   float64
+	}{
+		{name: "буквы и цифры", config: Config{UseDigits: true, UseLower: true, UseUpper: true}, bits: 80},
+		{name: "все наборы с повторами", config: Config{UseDigits: true, UseLower: true, UseUpper: true, UseSymbols: true, AllowRepeats: true}, bits: 128},
+		{name: "цифры с исключениями", config: Config{UseDigits: true, ExcludeChars: "01", AllowRepeats: true}, bits: 40},
+		{name: "цифры без повторов", config: Config{UseDigits: true}, bits: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			length := LengthForEntropy(tt.config, tt.bits)
+			if length <= 0 {
+				t.Fatalf("LengthForEntropy() = %d, want positive", length)
+			}
+
+			config := tt.config
+			config.Length = length
+			gen, err := NewGenerator(config)
+			if err != nil {
+				t.Fatalf("NewGenerator() failed: %v", err)
+			}
+			if got := gen.Entropy(); got < tt.bits {
+				t.Errorf("entropy at length %d = %.1f, want at least %.1f", length, got, tt.bits)
+			}
+
+			config.Length = length - 1
+			if shorter, err := NewGenerator(config); err == nil && shorter.Entropy() >= tt.bits {
+				t.Errorf("entropy at length %d = %.1f already reaches %.1f", length-1, shorter.Entropy(), tt.bits)
+			}
+		})
+	}
+}
+
+func TestLengthForEntropyUnreachable(t *testing.T) {
+	// 10 цифр без повторов дают не больше log2(10!) ≈ 21.8 бит
+	if got := LengthForEntropy(Config{UseDigits: true}, 30); got != 0 {
+		t.Errorf("LengthForEntropy() = %d, want 0", got)
+	}
+
+	if got := LengthForEntropy(Config{}, 30); got != 0 {
+		t.Errorf("LengthForEntropy() with empty charset = %d, want 0", got)
+	}
+}
+
+func TestConfigForEntropy(t *testing.T) {
+	alphanumeric := Config{UseDigits: true, UseLower: true, UseUpper: true}
+	withLength := func(config Config, length int) Config {
+		config.Length = length
+		return config
+	}
+
+	tests := []struct {
+		name       string
+		config     Config
+		bits       float64
+		count      int
+		wantLength int
+		wantErr    error
+	}{
+		{
+			name:       "миллион паролей по 60 бит",
+			config:     alphanumeric,
+			bits:       60,
+			count:      1000000,
+			wantLength: 11,
+		},
+		{
+			name:       "длину определяет количество",
+			config:     Config{UseDigits: true},
+			bits:       3,
+			count:      1000,
+			wantLength: 4, // 720 размещений из 10 по 3 не хватает
+		},
+		{
+			name:       "количество с повторами",
+			config:     Config{UseDigits: true, AllowRepeats: true},
+			bits:       3,
+			count:      1000,
+			wantLength: 3,
+		},
+		{
+			name:       "заданная длина проходит проверку",
+			config:     withLength(alphanumeric, 16),
+			bits:       60,
+			count:      1000000,
+			wantLength: 16,
+		},
+		{
+			name:    "заданная длина слишком короткая",
+			config:  withLength(alphanumeric, 10),
+			bits:    60,
+			count:   1000000,
+			wantErr: ErrInvalidConfig,
+		},
+		{
+			name:    "энтропия недостижима",
+			config:  Config{UseDigits: true},
+			bits:    30,
+			count:   1,
+			wantErr: ErrInvalidConfig,
+		},
+		{
+			name:    "количество недостижимо",
+			config:  Config{UseDigits: true},
+			bits:    10,
+			count:   4000000,
+			wantErr: ErrCharsetExhausted,
+		},
+		{
+			name:    "неположительное количество",
+			config:  alphanumeric,
+			bits:    60,
+			count:   0,
+			wantErr: ErrInvalidCount,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := ConfigForEntropy(tt.config, tt.bits, tt.count)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ConfigForEntropy() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ConfigForEntropy() failed: %v", err)
+			}
+			if config.Length != tt.wantLength {
+				t.Fatalf("Length = %d, want %d", config.Length, tt.wantLength)
+			}
+
+			// Выбранная длина действительно даёт нужные энтропию и количество
+			gen, err := NewGenerator(config)
+			if err != nil {
+				t.Fatalf("NewGenerator() failed: %v", err)
+			}
+			if gen.Entropy() < tt.bits {
+				t.Errorf("Entropy() = %.1f, want at least %.1f", gen.Entropy(), tt.bits)
+			}
+			if gen.MaxUnique().Cmp(big.NewInt(int64(tt.count))) < 0 {
+				t.Errorf("MaxUnique() = %s, want at least %d", gen.MaxUnique(), tt.count)
+			}
+		})
+	}
+}
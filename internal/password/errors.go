@@ -0,0 +1,41 @@
+package password
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Причины ошибок для проверки через errors.Is. Текст конкретной ошибки
+// по-прежнему описывает ситуацию подробно, а причина позволяет отличить,
+// например, некорректную конфигурацию от исчерпания комбинаций.
+var (
+	// ErrInvalidConfig - конфигурация генератора некорректна или невыполнима
+	ErrInvalidConfig = errors.New("некорректная конфигурация генератора")
+	// ErrInvalidCount - запрошено неположительное количество паролей
+	ErrInvalidCount = errors.New("некорректное количество паролей")
+	// ErrCharsetExhausted - подходящих паролей не осталось или их не удалось найти за лимит попыток
+	ErrCharsetExhausted = errors.New("исчерпаны возможные комбинации символов")
+	// ErrRandom - сбой источника случайности
+	ErrRandom = errors.New("ошибка источника случайности")
+	// ErrPolicyViolation - пароль, проверенный Validate, не соответствует политике
+	ErrPolicyViolation = errors.New("пароль не соответствует политике")
+)
+
+// kindError связывает ошибку с её причиной; текст ошибки не меняется
+type kindError struct {
+	kind error
+	err  error
+}
+
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+func (e *kindError) Unwrap() []error {
+	return []error{e.kind, e.err}
+}
+
+// errorf создаёт ошибку, как fmt.Errorf, с причиной kind для errors.Is
+func errorf(kind error, format string, args ...any) error {
+	return &kindError{kind: kind, err: fmt.Errorf(format, args...)}
+}
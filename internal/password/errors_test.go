@@ -0,0 +1,112 @@
+package password
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestSentinelErrors(t *testing.T) {
+	exhausted, err := NewGenerator(Config{Length: 1, UseDigits: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+	if _, err := exhausted.GenerateUnique(10); err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+
+	small, err := NewGenerator(Config{Length: 3, UseDigits: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		err  func() error
+		want error
+	}{
+		{
+			name: "некорректная длина",
+			err:  func() error { _, err := NewGenerator(Config{Length: -1, UseDigits: true}); return err },
+			want: ErrInvalidConfig,
+		},
+		{
+			name: "длина больше набора",
+			err:  func() error { _, err := NewGenerator(Config{Length: 11, UseDigits: true}); return err },
+			want: ErrInvalidConfig,
+		},
+		{
+			name: "нулевое количество",
+			err:  func() error { _, err := small.GenerateUnique(0); return err },
+			want: ErrInvalidCount,
+		},
+		{
+			name: "количество больше числа комбинаций",
+			err:  func() error { _, err := small.GenerateUnique(721); return err },
+			want: ErrCharsetExhausted,
+		},
+		{
+			name: "исчерпание при генерации",
+			err:  func() error { _, err := exhausted.Generate(); return err },
+			want: ErrCharsetExhausted,
+		},
+		{
+			name: "сбой источника случайности",
+			err: func() error {
+				gen, err := NewGeneratorWithReader(Config{Length: 4, UseLower: true}, DeterministicReader(nil))
+				if err != nil {
+					return err
+				}
+				_, err = gen.Generate()
+				return err
+			},
+			want: ErrRandom,
+		},
+	}
+
+	sentinels := []error{ErrInvalidConfig, ErrInvalidCount, ErrCharsetExhausted, ErrRandom}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.err()
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("errors.Is(%v, %v) = false", err, tt.want)
+			}
+			for _, other := range sentinels {
+				if other != tt.want && errors.Is(err, other) {
+					t.Errorf("errors.Is(%v, %v) = true, want false", err, other)
+				}
+			}
+		})
+	}
+}
+
+func TestSentinelErrorsKeepMessage(t *testing.T) {
+	_, err := NewGenerator(Config{Length: 11, UseDigits: true})
+	if err == nil {
+		t.Fatal("NewGenerator() expected error, got none")
+	}
+
+	// Причина не добавляется к тексту ошибки
+	if strings.Contains(err.Error(), ErrInvalidConfig.Error()) {
+		t.Errorf("error text %q should not include sentinel text", err)
+	}
+	if !strings.Contains(err.Error(), "превышает количество доступных уникальных символов") {
+		t.Errorf("error text = %q, want original message", err)
+	}
+}
+
+func TestErrorfWrapsCause(t *testing.T) {
+	// Сбой источника случайности сохраняет и причину ErrRandom, и исходную ошибку
+	err := errorf(ErrRandom, msg(msgRandomFailed), 3, io.ErrUnexpectedEOF)
+
+	if !errors.Is(err, ErrRandom) {
+		t.Errorf("errors.Is(%v, ErrRandom) = false", err)
+	}
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("errors.Is(%v, io.ErrUnexpectedEOF) = false", err)
+	}
+	if errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("errors.Is(%v, ErrInvalidConfig) = true, want false", err)
+	}
+}
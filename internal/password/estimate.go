@@ -0,0 +1,30 @@
+package password
+
+import "math/big"
+
+// EstimateResult описывает выполнимость генерации пачки паролей
+type EstimateResult struct {
+	Count       int      // запрошенное количество паролей
+	MaxUnique   *big.Int // теоретическое число различных паролей
+	EntropyBits float64  // энтропия конфигурации в битах
+	Feasible    bool     // можно ли получить Count уникальных паролей
+}
+
+// Estimate оценивает, можно ли сгенерировать count уникальных паролей,
+// ничего не генерируя и не изменяя множество использованных паролей.
+// Уже выданные генератором пароли вычитаются из доступного числа комбинаций.
+func (g *Generator) Estimate(count int) (EstimateResult, error) {
+	if count <= 0 {
+		return EstimateResult{}, errorf(ErrInvalidCount, msg(msgCountNotPositive))
+	}
+
+	maxUnique := g.MaxUnique()
+	available := new(big.Int).Sub(maxUnique, big.NewInt(int64(len(g.used))))
+
+	return EstimateResult{
+		Count:       count,
+		MaxUnique:   maxUnique,
+		EntropyBits: g.Entropy(),
+		Feasible:    big.NewInt(int64(count)).Cmp(available) <= 0,
+	}, nil
+}
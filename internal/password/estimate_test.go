@@ -0,0 +1,88 @@
+package password
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEstimate(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       Config
+		count        int
+		wantMax      int64
+		wantFeasible bool
+	}{
+		{
+			name:         "выполнимо",
+			config:       Config{Length: 3, UseDigits: true},
+			count:        720,
+			wantMax:      720,
+			wantFeasible: true,
+		},
+		{
+			name:         "невыполнимо",
+			config:       Config{Length: 3, UseDigits: true},
+			count:        721,
+			wantMax:      720,
+			wantFeasible: false,
+		},
+		{
+			name:         "с повторами",
+			config:       Config{Length: 3, UseDigits: true, AllowRepeats: true},
+			count:        1000,
+			wantMax:      1000,
+			wantFeasible: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen, err := NewGenerator(tt.config)
+			if err != nil {
+				t.Fatalf("NewGenerator() failed: %v", err)
+			}
+
+			result, err := gen.Estimate(tt.count)
+			if err != nil {
+				t.Fatalf("Estimate() failed: %v", err)
+			}
+
+			if result.MaxUnique.Int64() != tt.wantMax {
+				t.Errorf("MaxUnique = %s, want %d", result.MaxUnique, tt.wantMax)
+			}
+			if result.Feasible != tt.wantFeasible {
+				t.Errorf("Feasible = %v, want %v", result.Feasible, tt.wantFeasible)
+			}
+			if want := math.Log2(float64(tt.wantMax)); math.Abs(result.EntropyBits-want) > 1e-9 {
+				t.Errorf("EntropyBits = %f, want %f", result.EntropyBits, want)
+			}
+			if len(gen.used) != 0 {
+				t.Errorf("Estimate() touched used map: %d entries", len(gen.used))
+			}
+		})
+	}
+}
+
+func TestEstimateAccountsForUsed(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 1, UseDigits: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	if _, err := gen.GenerateUnique(5); err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+
+	result, err := gen.Estimate(6)
+	if err != nil {
+		t.Fatalf("Estimate() failed: %v", err)
+	}
+	if result.Feasible {
+		t.Error("Expected 6 more passwords to be infeasible after using 5 of 10")
+	}
+
+	if _, err := gen.Estimate(0); err == nil {
+		t.Error("Expected error for zero count, got none")
+	}
+}
@@ -0,0 +1,23 @@
+package password
+
+import "strings"
+
+// FormatGrouped разбивает пароль на группы по groupSize символов, разделённые sep,
+// например "ABCD-EFGH-IJ". Разделители не входят в длину пароля.
+// При groupSize <= 0 пароль возвращается без изменений.
+func FormatGrouped(password string, groupSize int, sep string) string {
+	runes := []rune(password)
+	if groupSize <= 0 || len(runes) <= groupSize {
+		return password
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(runes); i += groupSize {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		end := min(i+groupSize, len(runes))
+		b.WriteString(string(runes[i:end]))
+	}
+	return b.String()
+}
@@ -0,0 +1,30 @@
+package password
+
+import "testing"
+
+func TestFormatGrouped(t *testing.T) {
+	tests := []struct {
+		name      string
+		password  string
+		groupSize int
+		sep       string
+		want      string
+	}{
+		{name: "группы по 4", password: "ABCDEFGHIJKL", groupSize: 4, sep: "-", want: "ABCD-EFGH-IJKL"},
+		{name: "неполная последняя группа", password: "ABCDEFGHIJ", groupSize: 4, sep: "-", want: "ABCD-EFGH-IJ"},
+		{name: "пробел как разделитель", password: "abcdef", groupSize: 3, sep: " ", want: "abc def"},
+		{name: "многосимвольный разделитель", password: "abcdef", groupSize: 2, sep: "::", want: "ab::cd::ef"},
+		{name: "нулевой размер группы", password: "abcdef", groupSize: 0, sep: "-", want: "abcdef"},
+		{name: "отрицательный размер группы", password: "abcdef", groupSize: -2, sep: "-", want: "abcdef"},
+		{name: "группа больше пароля", password: "abc", groupSize: 5, sep: "-", want: "abc"},
+		{name: "не-ASCII символы", password: "абвгде", groupSize: 2, sep: "-", want: "аб-вг-де"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatGrouped(tt.password, tt.groupSize, tt.sep); got != tt.want {
+				t.Errorf("FormatGrouped(%q, %d, %q) = %q, want %q", tt.password, tt.groupSize, tt.sep, got, tt.want)
+			}
+		})
+	}
+}
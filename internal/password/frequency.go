@@ -0,0 +1,60 @@
+package password
+
+import "math"
+
+// skewSigmas - на сколько стандартных отклонений хи-квадрат должен превысить
+// ожидаемое значение, чтобы пачка считалась подозрительно перекошенной
+const skewSigmas = 6
+
+// FrequencyReport содержит частоты символов во всех паролях, выданных
+// генератором, и статистику хи-квадрат для аудита источника случайности
+type FrequencyReport struct {
+	Counts           map[rune]int // сколько раз встретился каждый символ набора
+	Total            int          // общее число символов
+	ChiSquare        float64      // сумма (наблюдаемое - ожидаемое)^2 / ожидаемое
+	DegreesOfFreedom int
+	Skewed           bool // хи-квадрат превышает ожидаемый на skewSigmas отклонений
+}
+
+// BatchFrequencyReport подсчитывает частоты символов во всех паролях,
+// сгенерированных с момента создания генератора (например, после GenerateUnique).
+// Ожидаемая частота считается внутри каждого набора: обязательные символы
+// и веса меняют долю наборов, но символы одного набора равновероятны.
+// Отчёт предназначен для аудита и ничего не отбрасывает.
+func (g *Generator) BatchFrequencyReport() FrequencyReport {
+	report := FrequencyReport{Counts: make(map[rune]int, len(g.charset))}
+	for _, r := range g.charset {
+		report.Counts[r] = 0
+	}
+
+	for password := range g.used {
+		for _, r := range password {
+			report.Counts[r]++
+			report.Total++
+		}
+	}
+
+	for _, group := range g.charsets {
+		groupTotal := 0
+		for _, r := range group {
+			groupTotal += report.Counts[r]
+		}
+		if groupTotal == 0 || len(group) < 2 {
+			continue
+		}
+
+		expected := float64(groupTotal) / float64(len(group))
+		for _, r := range group {
+			diff := float64(report.Counts[r]) - expected
+			report.ChiSquare += diff * diff / expected
+		}
+		report.DegreesOfFreedom += len(group) - 1
+	}
+
+	if report.DegreesOfFreedom > 0 {
+		df := float64(report.DegreesOfFreedom)
+		report.Skewed = report.ChiSquare > df+skewSigmas*math.Sqrt(2*df)
+	}
+
+	return report
+}
@@ -0,0 +1,81 @@
+package password
+
+import (
+	mathrand "math/rand"
+	"testing"
+)
+
+// skewedSource - детерминированный источник, который на каждом втором
+// вызове возвращает 0, из-за чего первый доступный символ выбирается
+// гораздо чаще остальных
+type skewedSource struct {
+	calls int
+	rand  mathrand.Source
+}
+
+func (s *skewedSource) Int63() int64 {
+	s.calls++
+	if s.calls%2 == 0 {
+		return 0
+	}
+	return s.rand.Int63()
+}
+
+func (s *skewedSource) Seed(seed int64) {
+	s.rand.Seed(seed)
+}
+
+func TestBatchFrequencyReportUniform(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 12, UseDigits: true, UseLower: true, UseUpper: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	if _, err := gen.GenerateUnique(2000); err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+
+	report := gen.BatchFrequencyReport()
+	if report.Total != 2000*12 {
+		t.Errorf("Total = %d, want %d", report.Total, 2000*12)
+	}
+	if len(report.Counts) != 62 {
+		t.Errorf("Counts has %d runes, want 62", len(report.Counts))
+	}
+	// Каждый из трёх наборов даёт размер-1 степеней свободы
+	if report.DegreesOfFreedom != 9+25+25 {
+		t.Errorf("DegreesOfFreedom = %d, want %d", report.DegreesOfFreedom, 9+25+25)
+	}
+	if report.Skewed {
+		t.Errorf("Uniform batch reported as skewed: chi-square = %.1f", report.ChiSquare)
+	}
+}
+
+func TestBatchFrequencyReportDetectsSkew(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 12, UseDigits: true, UseLower: true, UseUpper: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+	gen.rng = mathrand.New(&skewedSource{rand: mathrand.NewSource(1)})
+
+	if _, err := gen.GenerateUnique(2000); err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+
+	report := gen.BatchFrequencyReport()
+	if !report.Skewed {
+		t.Errorf("Skewed batch not detected: chi-square = %.1f, df = %d", report.ChiSquare, report.DegreesOfFreedom)
+	}
+}
+
+func TestBatchFrequencyReportEmpty(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 8, UseLower: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	report := gen.BatchFrequencyReport()
+	if report.Total != 0 || report.ChiSquare != 0 || report.Skewed {
+		t.Errorf("BatchFrequencyReport() on empty generator = %+v", report)
+	}
+}
@@ -1,32 +1,189 @@
 package password
 
 import (
+	"context"
 	"crypto/rand"
+	"errors"
 	"fmt"
+	"io"
 	"math/big"
+	mathrand "math/rand"
+	"slices"
 )
 
 // Config содержит параметры для генерации пароля
 type Config struct {
-	Length    int
-	UseDigits bool
-	UseLower  bool
-	UseUpper  bool
+	Length       int    `json:"length"`
+	MinLength    int    `json:"min_length"` // минимальная длина, если Length не задан
+	MaxLength    int    `json:"max_length"` // максимальная длина, если Length не задан
+	UseDigits    bool   `json:"use_digits"`
+	UseLower     bool   `json:"use_lower"`
+	UseUpper     bool   `json:"use_upper"`
+	UseSymbols   bool   `json:"use_symbols"`
+	UseEmoji     bool   `json:"use_emoji"`     // эмодзи из безопасного списка, каждый - одна руна
+	CustomChars  string `json:"custom_chars"`  // дополнительный набор символов, допускаются любые руны Unicode
+	ExcludeChars string `json:"exclude_chars"` // символы, которые не должны попадать в пароль
+	AllowRepeats bool   `json:"allow_repeats"` // разрешить повторение символов внутри пароля
+
+	// MaxCharOccurrences разрешает каждому символу встречаться в пароле не
+	// больше K раз (0 - по AllowRepeats). K = 1 равносильно запрету повторов,
+	// K > 1 включает повторы независимо от AllowRepeats
+	MaxCharOccurrences int `json:"max_char_occurrences"`
+
+	// SafeSymbolsOnly заменяет набор спецсимволов на "-_.+=", безопасный для
+	// URL и командной оболочки. Действует только вместе с UseSymbols
+	SafeSymbolsOnly bool `json:"safe_symbols_only"`
+
+	// Prefix и Suffix добавляются к случайной части как есть. Length, наборы,
+	// запрет повторов, правила и энтропия относятся только к случайной части
+	Prefix string `json:"prefix"`
+	Suffix string `json:"suffix"`
+
+	// CustomGroups - дополнительные именованные наборы, каждый со своим минимумом
+	CustomGroups []CustomGroup `json:"custom_groups"`
+
+	// MaxConsecutive ограничивает число одинаковых символов подряд (0 - без ограничения)
+	MaxConsecutive int `json:"max_consecutive"`
+	// NoAdjacentRepeats запрещает два одинаковых символа рядом ("aa"), но при
+	// AllowRepeats допускает повторы в разных местах пароля. Равносильно
+	// MaxConsecutive = 1
+	NoAdjacentRepeats bool `json:"no_adjacent_repeats"`
+	// MaxSequential ограничивает длину последовательностей вида "abc" или "321" (0 - без ограничения)
+	MaxSequential int `json:"max_sequential"`
+	// AvoidKeyboardSequences отбрасывает пароли с клавиатурными сериями вроде "qwer" или "asdf"
+	AvoidKeyboardSequences bool `json:"avoid_keyboard_sequences"`
+	// AvoidYears отбрасывает пароли с годами вроде "1990" или "2023" (1900-2099).
+	// Действует только при включённых цифрах
+	AvoidYears bool `json:"avoid_years"`
+	// RequireEachSet требует хотя бы один символ из каждого набора, если их
+	// несколько. nil означает true; false - равномерный выбор из общего набора
+	// без гарантий присутствия наборов
+	RequireEachSet *bool `json:"require_each_set"`
+	// MinClasses требует символы хотя бы из MinClasses разных наборов вместо
+	// обязательного символа из каждого (0 - см. RequireEachSet)
+	MinClasses int `json:"min_classes"`
+	// NoLeadingDigit запрещает пароли, начинающиеся с цифры
+	NoLeadingDigit bool `json:"no_leading_digit"`
+	// RequireSymbolAtEnd требует спецсимвол в последней позиции (нужен UseSymbols)
+	RequireSymbolAtEnd bool `json:"require_symbol_at_end"`
+	// AlternateClasses расставляет символы так, чтобы соседние символы были
+	// из разных наборов (нужно хотя бы два набора)
+	AlternateClasses bool `json:"alternate_classes"`
+
+	// Blocklist - запрещённые (например, скомпрометированные) пароли, которые никогда не выдаются
+	Blocklist []string `json:"blocklist"`
+	// BlocklistIgnoreCase включает сравнение с Blocklist без учёта регистра
+	BlocklistIgnoreCase bool `json:"blocklist_ignore_case"`
+
+	// MinEntropyBits - минимально допустимая энтропия конфигурации в битах (0 - без проверки)
+	MinEntropyBits float64 `json:"min_entropy_bits"`
+
+	// Weights задаёт относительную частоту наборов при заполнении пароля после
+	// обязательных символов. Ключи: "digits", "lower", "upper", "symbols", "emoji", "custom".
+	// Наборы, отсутствующие в карте, получают вес 0 и попадают в пароль только
+	// обязательным символом. Пустая карта - равномерный выбор по всем символам.
+	Weights map[string]int `json:"weights"`
+
+	// MaxAttempts - лимит попыток на один пароль (0 - значение по умолчанию:
+	// 10 попыток на каждую возможную комбинацию, от 10000 до 1000000)
+	MaxAttempts int `json:"max_attempts"`
+
+	// Store - внешнее хранилище использованных паролей для уникальности между запусками
+	Store UsedStore `json:"-"`
+
+	// Progress вызывается при генерации пачки (GenerateUnique и другие) после
+	// каждого процента паролей и в конце: done - готово, total - всего
+	Progress func(done, total int) `json:"-"`
+
+	// Accept - дополнительное условие для правил, не покрытых конфигурацией:
+	// кандидаты, для которых оно возвращает false, перегенерируются в
+	// пределах MaxAttempts. Получает пароль целиком, с префиксом и суффиксом.
+	Accept func(password string) bool `json:"-"`
 }
 
 // Generator генерирует уникальные пароли
 type Generator struct {
-	charset     []rune
-	charsets    [][]rune
-	length      int
-	used        map[string]struct{}
-	maxAttempts int
+	charset      []rune
+	charsets     [][]rune
+	weights      []int // веса наборов charsets для заполнения, nil - равномерный выбор
+	groupMins    []int // минимумы наборов charsets из CustomGroups, nil - без минимумов
+	length       int
+	minLength    int
+	maxLength    int
+	allowRepeats bool
+	maxOccur     int // предел вхождений символа при повторах, 0 - без предела (см. Config.MaxCharOccurrences)
+	prefix       string
+	suffix       string
+	used         map[string]struct{}
+	reserved     []string // пароли, отложенные Reserve для выдачи через Next
+	maxAttempts  int
+	attempts     int // общее число попыток генерации, см. GenerateUniqueWithStats
+
+	maxConsecutive int
+	maxSequential  int
+	avoidKeyboard  bool
+	avoidYears     bool
+	noLeadingDigit bool
+	minClasses     int
+	requireEachSet bool
+
+	requireSymbolAtEnd bool
+	symbolGroup        int // индекс набора symbols в charsets при RequireSymbolAtEnd, иначе -1
+	alternateClasses   bool
+
+	blocklist           map[string]struct{}
+	blocklistIgnoreCase bool
+
+	store    UsedStore
+	progress func(done, total int) // см. Config.Progress
+	accept   func(string) bool     // см. Config.Accept
+
+	digitsOnly  bool // набор - ровно 10 цифр с повторами, см. generateDigits (только для crypto/rand)
+	skipShuffle bool // порядок символов и так равномерно случаен, см. generateRunes (только для crypto/rand)
+
+	rng    *mathrand.Rand // детерминированный источник, только для тестов (см. NewSeededGenerator)
+	random io.Reader      // источник байт для rand.Int, по умолчанию crypto/rand (см. NewGeneratorWithReader)
 }
 
+// RandomRetries - число повторов чтения из источника случайности после
+// ошибки. Нехватка энтропии в системе бывает кратковременной, поэтому
+// ошибка возвращается как ErrRandom только после всех повторов. Задаётся
+// при старте программы, как Language.
+var RandomRetries = 2
+
+// Лимит попыток по умолчанию зависит от числа возможных паролей: когда
+// почти все комбинации уже выданы, последнюю свободную случайный перебор
+// находит в среднем за MaxUnique попыток, поэтому лимит - attemptsPerCombination
+// попыток на комбинацию, но не меньше defaultMaxAttempts (запас для правил,
+// отбрасывающих кандидатов) и не больше maxDefaultAttempts.
 const (
-	digits = "0123456789"
-	lower  = "abcdefghijklmnopqrstuvwxyz"
-	upper  = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	defaultMaxAttempts     = 10000
+	maxDefaultAttempts     = 1000000
+	attemptsPerCombination = 10
+)
+
+// defaultAttempts возвращает лимит попыток по умолчанию для maxUnique
+// возможных паролей
+func defaultAttempts(maxUnique *big.Int) int {
+	limit := new(big.Int).Mul(maxUnique, big.NewInt(attemptsPerCombination))
+	switch {
+	case limit.Cmp(big.NewInt(defaultMaxAttempts)) < 0:
+		return defaultMaxAttempts
+	case limit.Cmp(big.NewInt(maxDefaultAttempts)) > 0:
+		return maxDefaultAttempts
+	}
+	return int(limit.Int64())
+}
+
+const (
+	digits  = "0123456789"
+	lower   = "abcdefghijklmnopqrstuvwxyz"
+	upper   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	symbols = "!@#$%^&*()-_=+[]{}<>?,.;:"
+
+	// safeSymbols - спецсимволы, которые не требуют экранирования в URL и
+	// командной оболочке (см. Config.SafeSymbolsOnly)
+	safeSymbols = "-_.+="
 )
 
 // NewGenerator создаёт новый генератор паролей с валидацией конфигурации
@@ -35,151 +192,638 @@ func NewGenerator(config Config) (*Generator, error) {
 		return nil, err
 	}
 
-	charset, charsets := buildCharset(config)
+	// Предел в одно вхождение - это обычный запрет повторов
+	switch {
+	case config.MaxCharOccurrences == 1:
+		config.AllowRepeats = false
+		config.MaxCharOccurrences = 0
+	case config.MaxCharOccurrences > 1:
+		config.AllowRepeats = true
+	}
+
+	charset, charsets, names := buildCharset(config)
+
+	if len(charset) == 0 {
+		return nil, errorf(ErrInvalidConfig, msg(msgCharsetEmpty))
+	}
+
+	maxLength := config.Length
+	if maxLength == 0 {
+		maxLength = config.MaxLength
+	}
+
+	if config.NoLeadingDigit && !hasNonDigit(charset) {
+		return nil, errorf(ErrInvalidConfig, msg(msgLeadingDigitOnlyDigits))
+	}
+
+	if config.MinClasses > len(charsets) {
+		return nil, errorf(ErrInvalidConfig, msg(msgMinClassesExceedsSets), config.MinClasses, len(charsets))
+	}
+
+	shortest := config.Length
+	if shortest == 0 {
+		shortest = config.MinLength
+	}
+	if config.MinClasses > shortest {
+		return nil, errorf(ErrInvalidConfig, msg(msgLengthBelowMinClasses), shortest, config.MinClasses)
+	}
+
+	if config.RequireSymbolAtEnd && !slices.Contains(names, "symbols") {
+		return nil, errorf(ErrInvalidConfig, msg(msgSymbolAtEndNoSymbols))
+	}
+
+	if !config.AllowRepeats && maxLength > len(charset) {
+		return nil, errorf(ErrInvalidConfig, msg(msgLengthExceedsCharset), maxLength, len(charset))
+	}
+
+	if limit := config.MaxCharOccurrences * len(charset); limit > 0 && maxLength > limit {
+		return nil, errorf(ErrInvalidConfig, msg(msgLengthExceedsOccurrences), maxLength, limit, config.MaxCharOccurrences)
+	}
+
+	weights, err := groupWeights(config.Weights, charsets, names)
+	if err != nil {
+		return nil, err
+	}
+
+	requireEachSet := config.RequireEachSet == nil || *config.RequireEachSet
+	if err := checkExcludedSets(config, requireEachSet); err != nil {
+		return nil, err
+	}
+
+	groupMins, err := groupMinimums(config.CustomGroups, charsets, names, config.AllowRepeats)
+	if err != nil {
+		return nil, err
+	}
+	if required := requiredCount(len(charsets), groupMins, requireEachSet, config.MinClasses); required > shortest {
+		return nil, errorf(ErrInvalidConfig, msg(msgRequiredExceedsLength), shortest, required)
+	}
+
+	if config.AlternateClasses {
+		if err := checkAlternateClasses(charsets, maxLength, config.AllowRepeats, config.MaxCharOccurrences); err != nil {
+			return nil, err
+		}
+	}
+
+	if capacity := weightedCapacity(charsets, weights, requireEachSet); !config.AllowRepeats && weights != nil && maxLength > capacity {
+		return nil, errorf(ErrInvalidConfig, msg(msgLengthExceedsWeighted), maxLength, capacity)
+	}
+
+	maxConsecutive := config.MaxConsecutive
+	if config.NoAdjacentRepeats {
+		maxConsecutive = 1
+	}
+
+	gen := &Generator{
+		charset:      charset,
+		charsets:     charsets,
+		weights:      weights,
+		groupMins:    groupMins,
+		length:       config.Length,
+		minLength:    config.MinLength,
+		maxLength:    config.MaxLength,
+		allowRepeats: config.AllowRepeats,
+		maxOccur:     config.MaxCharOccurrences,
+		prefix:       config.Prefix,
+		suffix:       config.Suffix,
+		used:         make(map[string]struct{}),
+		maxAttempts:  config.MaxAttempts,
+
+		maxConsecutive: maxConsecutive,
+		maxSequential:  config.MaxSequential,
+		avoidKeyboard:  config.AvoidKeyboardSequences,
+		avoidYears:     config.AvoidYears && config.UseDigits,
+		noLeadingDigit: config.NoLeadingDigit,
+		minClasses:     config.MinClasses,
+		requireEachSet: requireEachSet,
+
+		requireSymbolAtEnd: config.RequireSymbolAtEnd,
+		symbolGroup:        -1,
+		alternateClasses:   config.AlternateClasses,
+
+		blocklist:           buildBlocklist(config.Blocklist, config.BlocklistIgnoreCase),
+		blocklistIgnoreCase: config.BlocklistIgnoreCase,
+
+		store:    config.Store,
+		progress: config.Progress,
+		accept:   config.Accept,
+	}
 
-	if config.Length > len(charset) {
-		return nil, fmt.Errorf("длина пароля (%d) превышает количество доступных уникальных символов (%d)", config.Length, len(charset))
+	if config.RequireSymbolAtEnd {
+		gen.symbolGroup = slices.Index(names, "symbols")
 	}
 
-	return &Generator{
-		charset:     charset,
-		charsets:    charsets,
-		length:      config.Length,
-		used:        make(map[string]struct{}),
-		maxAttempts: 10000, // разумный лимит попыток
-	}, nil
+	gen.digitsOnly = string(charset) == digits && config.AllowRepeats && config.MaxCharOccurrences == 0 && weights == nil
+
+	// С одним набором каждая позиция заполняется равномерным выбором из
+	// оставшихся символов, поэтому перемешивание ничего не меняет. С пределом
+	// вхождений это не так: набор доступных символов зависит от порядка
+	gen.skipShuffle = len(charsets) == 1 && config.MaxCharOccurrences == 0 && weights == nil
+
+	if gen.maxAttempts == 0 {
+		gen.maxAttempts = defaultAttempts(gen.MaxUnique())
+	}
+
+	if err := checkMinEntropy(gen, config.MinEntropyBits); err != nil {
+		return nil, err
+	}
+
+	return gen, nil
+}
+
+// checkMinEntropy проверяет, что энтропия генератора не ниже minBits,
+// и подсказывает, как усилить конфигурацию
+func checkMinEntropy(gen *Generator, minBits float64) error {
+	if minBits <= 0 {
+		return nil
+	}
+
+	bits := gen.Entropy()
+	if bits >= minBits {
+		return nil
+	}
+
+	needed := minLengthForEntropy(len(gen.charset), minBits, gen.allowRepeats)
+	if needed == 0 {
+		return errorf(ErrInvalidConfig, msg(msgEntropyTooLowSets), bits, minBits)
+	}
+
+	return errorf(ErrInvalidConfig, msg(msgEntropyTooLowLength), bits, minBits, needed)
 }
 
 // validateConfig проверяет корректность конфигурации
 func validateConfig(config Config) error {
-	if config.Length <= 0 {
-		return fmt.Errorf("длина пароля должна быть положительным числом")
+	if config.Length < 0 {
+		return errorf(ErrInvalidConfig, msg(msgLengthNotPositive))
+	}
+
+	if config.Length == 0 {
+		if config.MinLength <= 0 && config.MaxLength <= 0 {
+			return errorf(ErrInvalidConfig, msg(msgLengthNotPositive))
+		}
+
+		if config.MinLength <= 0 {
+			return errorf(ErrInvalidConfig, msg(msgMinLengthNotPositive))
+		}
+
+		if config.MaxLength < config.MinLength {
+			return errorf(ErrInvalidConfig, msg(msgMaxLengthBelowMin), config.MaxLength, config.MinLength)
+		}
+	}
+
+	if !config.UseDigits && !config.UseLower && !config.UseUpper && !config.UseSymbols && !config.UseEmoji && config.CustomChars == "" && len(config.CustomGroups) == 0 {
+		return errorf(ErrInvalidConfig, msg(msgNoCharsets))
+	}
+
+	if config.MaxConsecutive < 0 {
+		return errorf(ErrInvalidConfig, msg(msgNegativeMaxConsecutive))
+	}
+
+	if config.MaxSequential < 0 {
+		return errorf(ErrInvalidConfig, msg(msgNegativeMaxSequential))
+	}
+
+	if config.MinClasses < 0 {
+		return errorf(ErrInvalidConfig, msg(msgNegativeMinClasses))
+	}
+
+	if config.MaxCharOccurrences < 0 {
+		return errorf(ErrInvalidConfig, msg(msgNegativeMaxOccurrences))
 	}
 
-	if !config.UseDigits && !config.UseLower && !config.UseUpper {
-		return fmt.Errorf("необходимо выбрать хотя бы один набор символов (digits, lower или upper)")
+	if config.MaxAttempts < 0 {
+		return errorf(ErrInvalidConfig, msg(msgNegativeMaxAttempts))
+	}
+
+	if config.MinEntropyBits < 0 {
+		return errorf(ErrInvalidConfig, msg(msgNegativeMinEntropy))
+	}
+
+	if err := checkSingleCodePoints("custom", config.CustomChars); err != nil {
+		return err
+	}
+
+	if err := validateCustomGroups(config.CustomGroups); err != nil {
+		return err
+	}
+
+	for name, weight := range config.Weights {
+		isCustomGroup := slices.ContainsFunc(config.CustomGroups, func(g CustomGroup) bool { return g.Name == name })
+		if !slices.Contains(groupNames, name) && !isCustomGroup {
+			return errorf(ErrInvalidConfig, msg(msgUnknownWeightSet), name)
+		}
+		if weight < 0 {
+			return errorf(ErrInvalidConfig, msg(msgNegativeWeight), name)
+		}
 	}
 
 	return nil
 }
 
-// buildCharset создаёт общий набор символов и группы для валидации
-func buildCharset(config Config) ([]rune, [][]rune) {
+// groupNames - имена наборов в порядке их добавления в buildCharset
+var groupNames = []string{"digits", "lower", "upper", "symbols", "emoji", "custom"}
+
+// buildCharset создаёт общий набор символов и группы для валидации,
+// а также имена групп (см. groupNames). Исключённые символы удаляются,
+// а опустевшие группы не учитываются. Наборы и исключения приводятся к NFC.
+func buildCharset(config Config) ([]rune, [][]rune, []string) {
 	var charset []rune
 	var charsets [][]rune
+	var names []string
+
+	exclude := normalizeChars(config.ExcludeChars)
+	addGroup := func(name, group string) {
+		groupRunes := uniqueRunes(excludeRunes([]rune(normalizeChars(group)), exclude), charset)
+		if len(groupRunes) == 0 {
+			return
+		}
+		charset = append(charset, groupRunes...)
+		charsets = append(charsets, groupRunes)
+		names = append(names, name)
+	}
 
 	if config.UseDigits {
-		digitsRunes := []rune(digits)
-		charset = append(charset, digitsRunes...)
-		charsets = append(charsets, digitsRunes)
+		addGroup("digits", digits)
 	}
 
 	if config.UseLower {
-		lowerRunes := []rune(lower)
-		charset = append(charset, lowerRunes...)
-		charsets = append(charsets, lowerRunes)
+		addGroup("lower", lower)
 	}
 
 	if config.UseUpper {
-		upperRunes := []rune(upper)
-		charset = append(charset, upperRunes...)
-		charsets = append(charsets, upperRunes)
+		addGroup("upper", upper)
+	}
+
+	if config.UseSymbols {
+		if config.SafeSymbolsOnly {
+			addGroup("symbols", safeSymbols)
+		} else {
+			addGroup("symbols", symbols)
+		}
+	}
+
+	if config.UseEmoji {
+		addGroup("emoji", emoji)
+	}
+
+	if config.CustomChars != "" {
+		addGroup("custom", config.CustomChars)
+	}
+
+	for _, group := range config.CustomGroups {
+		addGroup(group.Name, group.Chars)
 	}
 
-	return charset, charsets
+	return charset, charsets, names
+}
+
+// uniqueRunes возвращает символы группы без дубликатов и без символов,
+// уже присутствующих в existing
+func uniqueRunes(group []rune, existing []rune) []rune {
+	var result []rune
+	for _, r := range group {
+		if !containsRune(existing, r) && !containsRune(result, r) {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// excludeRunes возвращает символы группы без символов из exclude
+func excludeRunes(group []rune, exclude string) []rune {
+	if exclude == "" {
+		return group
+	}
+
+	excluded := []rune(exclude)
+	var result []rune
+	for _, r := range group {
+		if !containsRune(excluded, r) {
+			result = append(result, r)
+		}
+	}
+	return result
 }
 
 // Generate генерирует один уникальный пароль
 func (g *Generator) Generate() (string, error) {
+	return g.generateAvoiding(nil, 0)
+}
+
+// generateAvoiding генерирует уникальный пароль, как Generate. Первые
+// softAttempts попыток дополнительно отбрасывают кандидатов, для которых
+// avoid возвращает true; после этого подходит любой уникальный кандидат.
+// avoid получает пароль целиком, с префиксом и суффиксом.
+func (g *Generator) generateAvoiding(avoid func(string) bool, softAttempts int) (string, error) {
 	for attempt := 0; attempt < g.maxAttempts; attempt++ {
+		g.attempts++
+
 		password, err := g.generateOne()
 		if err != nil {
 			return "", err
 		}
 
-		// Проверяем уникальность
-		if _, exists := g.used[password]; !exists {
-			g.used[password] = struct{}{}
-			return password, nil
+		// Отбрасываем кандидатов, нарушающих правила, и пробуем снова
+		if !g.satisfiesRules(password) {
+			continue
+		}
+
+		// Проверяем уникальность, в том числе по внешнему хранилищу,
+		// где хранятся пароли целиком вместе с префиксом и суффиксом
+		if _, exists := g.used[password]; exists {
+			continue
+		}
+		full := g.withAffixes(password)
+		if g.store != nil && g.store.Has(full) {
+			continue
+		}
+
+		if g.accept != nil && !g.accept(full) {
+			continue
+		}
+
+		if avoid != nil && attempt < softAttempts && avoid(full) {
+			continue
+		}
+
+		if g.store != nil {
+			if err := g.store.Add(full); err != nil {
+				return "", fmt.Errorf(msg(msgStoreSaveFailed), err)
+			}
 		}
+		g.used[password] = struct{}{}
+		return full, nil
 	}
 
-	return "", fmt.Errorf("не удалось сгенерировать уникальный пароль за %d попыток, возможно достигнут лимит комбинаций", g.maxAttempts)
+	return "", errorf(ErrCharsetExhausted, msg(msgUniqueAttemptsExhausted), g.maxAttempts)
 }
 
 // generateOne генерирует один пароль (без проверки уникальности)
 func (g *Generator) generateOne() (string, error) {
-	// Создаём временную копию доступных символов
+	length, err := g.nextLength()
+	if err != nil {
+		return "", err
+	}
+
+	// Детерминированные источники идут общим путём, чтобы при том же зерне
+	// или потоке байт получались те же пароли, что и раньше
+	if g.digitsOnly && g.rng == nil && g.random == nil {
+		return g.generateDigits(length)
+	}
+
+	result, err := g.generateRunes(length)
+	if err != nil {
+		return "", err
+	}
+	return string(result), nil
+}
+
+// generateRunes генерирует символы пароля длины length без префикса и
+// суффикса и без проверки правил и уникальности
+func (g *Generator) generateRunes(length int) ([]rune, error) {
+	// Создаём временную копию доступных символов. Активна только часть
+	// available[:n]: выбранный символ переставляется в конец и отсекается
+	// (частичный Fisher-Yates), поэтому удаление стоит O(1)
 	available := make([]rune, len(g.charset))
 	copy(available, g.charset)
+	n := len(available)
 
-	var result []rune
+	// При пределе вхождений символ убирается из доступных, когда
+	// встретился maxOccur раз
+	var counts map[rune]int
+	if g.maxOccur > 0 {
+		counts = make(map[rune]int)
+	}
 
-	// Если используется несколько наборов, гарантируем минимум один символ из каждого
-	if len(g.charsets) > 1 {
-		for _, charsetGroup := range g.charsets {
-			// Находим символы из этой группы, которые ещё доступны
-			var availableFromGroup []int
-			for i, char := range available {
-				if containsRune(charsetGroup, char) {
-					availableFromGroup = append(availableFromGroup, i)
-				}
+	take := func(idx int) rune {
+		char := available[idx]
+		exhausted := !g.allowRepeats
+		if counts != nil {
+			counts[char]++
+			exhausted = counts[char] >= g.maxOccur
+		}
+		if exhausted {
+			n--
+			available[idx], available[n] = available[n], available[idx]
+		}
+		return char
+	}
+
+	result := make([]rune, 0, length)
+
+	// Гарантируем минимум один символ из каждого обязательного набора
+	required, err := g.requiredGroups()
+	if err != nil {
+		return nil, err
+	}
+	for _, charsetGroup := range required {
+		randIdx, err := g.randomInt(len(charsetGroup))
+		if err != nil {
+			return nil, err
+		}
+
+		// Набор с минимумом больше одного встречается несколько раз, и выбранный
+		// символ может быть уже занят - тогда выбираем среди оставшихся
+		selectedIdx := indexRune(available[:n], charsetGroup[randIdx])
+		if selectedIdx < 0 {
+			if selectedIdx, err = g.pickAvailable(charsetGroup, available[:n]); err != nil {
+				return nil, err
 			}
+		}
+		result = append(result, take(selectedIdx))
+	}
+
+	// При чередовании наборов ни один набор не должен занять больше половины
+	// позиций, иначе соседей из одного набора не избежать
+	var classCounts []int
+	if g.alternateClasses {
+		classCounts = make([]int, len(g.charsets))
+		for _, r := range result {
+			classCounts[g.classOf(r)]++
+		}
+	}
 
-			if len(availableFromGroup) == 0 {
-				return "", fmt.Errorf("недостаточно символов для удовлетворения требований")
+	// Заполняем оставшиеся позиции
+	if g.weights != nil {
+		result, err = g.fillWeighted(result, length)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		remaining := length - len(result)
+		for i := 0; i < remaining; i++ {
+			if n == 0 {
+				return nil, errorf(ErrCharsetExhausted, msg(msgNotEnoughUnique))
 			}
 
-			// Выбираем случайный символ из этой группы
-			randIdx, err := secureRandomInt(len(availableFromGroup))
+			var randIdx int
+			if classCounts != nil {
+				randIdx, err = g.pickUnderCap(available[:n], classCounts, (length+1)/2)
+			} else {
+				randIdx, err = g.randomInt(n)
+			}
 			if err != nil {
-				return "", err
+				return nil, err
 			}
 
-			selectedIdx := availableFromGroup[randIdx]
-			result = append(result, available[selectedIdx])
+			char := take(randIdx)
+			if classCounts != nil {
+				classCounts[g.classOf(char)]++
+			}
+			result = append(result, char)
+		}
+	}
 
-			// Удаляем выбранный символ из available
-			available = removeAtIndex(available, selectedIdx)
+	// Перемешиваем результат: обязательные символы стоят в начале.
+	// Детерминированные источники перемешивают всегда, чтобы при том же
+	// зерне или потоке байт получались те же пароли, что и раньше
+	if !g.skipShuffle || g.rng != nil || g.random != nil {
+		if err := shuffle(result, g.randomInt); err != nil {
+			return nil, err
 		}
 	}
 
-	// Заполняем оставшиеся позиции
-	remaining := g.length - len(result)
-	for i := 0; i < remaining; i++ {
-		if len(available) == 0 {
-			return "", fmt.Errorf("недостаточно уникальных символов")
+	if g.requireSymbolAtEnd {
+		if err := g.moveSymbolToEnd(result); err != nil {
+			return nil, err
 		}
+	}
 
-		randIdx, err := secureRandomInt(len(available))
-		if err != nil {
-			return "", err
+	if g.alternateClasses {
+		if err := g.arrangeAlternating(result); err != nil {
+			return nil, err
 		}
+	}
+
+	return result, nil
+}
 
-		result = append(result, available[randIdx])
-		available = removeAtIndex(available, randIdx)
+// requiredGroups возвращает наборы, из которых пароль обязан содержать символ:
+// при MinClasses - случайно выбранные MinClasses наборов, иначе все наборы,
+// если их несколько и не отключён RequireEachSet. Набор с минимумом из
+// CustomGroups повторяется в списке столько раз, сколько символов он требует.
+// При RequireSymbolAtEnd набор symbols обязателен всегда, а с MinClasses
+// считается одним из выбранных наборов.
+func (g *Generator) requiredGroups() ([][]rune, error) {
+	counts := make([]int, len(g.charsets))
+
+	if g.minClasses > 0 {
+		// Частичный Fisher-Yates по индексам наборов
+		indexes := make([]int, len(g.charsets))
+		for i := range indexes {
+			indexes[i] = i
+		}
+		start := 0
+		if g.symbolGroup >= 0 {
+			indexes[0], indexes[g.symbolGroup] = indexes[g.symbolGroup], indexes[0]
+			counts[g.symbolGroup] = 1
+			start = 1
+		}
+		for i := start; i < g.minClasses; i++ {
+			j, err := g.randomInt(len(indexes) - i)
+			if err != nil {
+				return nil, err
+			}
+			indexes[i], indexes[i+j] = indexes[i+j], indexes[i]
+			counts[indexes[i]] = 1
+		}
+	} else if g.requireEachSet && len(g.charsets) > 1 {
+		for i := range counts {
+			counts[i] = 1
+		}
+	}
+	if g.symbolGroup >= 0 {
+		counts[g.symbolGroup] = max(counts[g.symbolGroup], 1)
 	}
 
-	// Перемешиваем результат
-	if err := shuffle(result); err != nil {
-		return "", err
+	var required [][]rune
+	for i, group := range g.charsets {
+		if g.groupMins != nil {
+			counts[i] = max(counts[i], g.groupMins[i])
+		}
+		for k := 0; k < counts[i]; k++ {
+			required = append(required, group)
+		}
 	}
+	return required, nil
+}
 
-	return string(result), nil
+// Clone создаёт независимый генератор с теми же настройками, но с пустым
+// множеством использованных паролей и пустым пулом Reserve. Валидация и сборка набора символов
+// не повторяются. Внешнее хранилище (Config.Store) остаётся общим.
+//
+// Клон генератора с зерном (NewSeededGenerator) получает собственный
+// источник, зерно которого берётся из источника родителя: последовательности
+// детерминированы и не мешают друг другу. Источник r из
+// NewGeneratorWithReader остаётся общим, как и Store: клон и родитель
+// читают один поток байт, и одновременно их можно использовать, только
+// если r безопасен для конкурентного чтения.
+func (g *Generator) Clone() *Generator {
+	clone := *g
+	clone.used = make(map[string]struct{})
+	clone.reserved = nil
+	clone.attempts = 0
+	if g.rng != nil {
+		clone.rng = mathrand.New(mathrand.NewSource(g.rng.Int63()))
+	}
+	return &clone
+}
+
+// Charset возвращает итоговый набор символов генератора после всех
+// включений и исключений: уникальные руны в порядке возрастания кодов
+func (g *Generator) Charset() string {
+	runes := slices.Clone(g.charset)
+	slices.Sort(runes)
+	return string(runes)
+}
+
+// nextLength возвращает длину очередного пароля: фиксированную или
+// случайную из диапазона [minLength, maxLength]
+func (g *Generator) nextLength() (int, error) {
+	if g.length > 0 {
+		return g.length, nil
+	}
+
+	offset, err := g.randomInt(g.maxLength - g.minLength + 1)
+	if err != nil {
+		return 0, err
+	}
+
+	return g.minLength + offset, nil
 }
 
 // GenerateUnique генерирует count уникальных паролей
 func (g *Generator) GenerateUnique(count int) ([]string, error) {
+	return g.GenerateUniqueContext(context.Background(), count)
+}
+
+// checkUniqueCount проверяет, что count положителен и столько уникальных
+// паролей вообще существует
+func (g *Generator) checkUniqueCount(count int) error {
 	if count <= 0 {
-		return nil, fmt.Errorf("количество паролей должно быть положительным числом")
+		return errorf(ErrInvalidCount, msg(msgCountNotPositive))
+	}
+
+	if maxUnique := g.MaxUnique(); big.NewInt(int64(count)).Cmp(maxUnique) > 0 {
+		return errorf(ErrCharsetExhausted, msg(msgCountExceedsMaxUnique), count, maxUnique)
 	}
 
-	var result []string
+	return nil
+}
+
+// GenerateMany генерирует count независимых паролей без проверки уникальности:
+// совпадения внутри пачки возможны, зато нет накладных расходов на used
+// и нет ошибки исчерпания комбинаций. Дополнительные правила конфигурации
+// по-прежнему соблюдаются.
+func (g *Generator) GenerateMany(count int) ([]string, error) {
+	if count <= 0 {
+		return nil, errorf(ErrInvalidCount, msg(msgCountNotPositive))
+	}
 
+	result := make([]string, 0, count)
 	for i := 0; i < count; i++ {
-		password, err := g.Generate()
+		password, err := g.generateValid()
 		if err != nil {
-			return nil, fmt.Errorf("не удалось сгенерировать %d уникальных паролей: %w", count, err)
+			return nil, err
 		}
 		result = append(result, password)
 	}
@@ -187,24 +831,130 @@ func (g *Generator) GenerateUnique(count int) ([]string, error) {
 	return result, nil
 }
 
+// generateValid генерирует пароль, удовлетворяющий правилам, без проверки уникальности
+func (g *Generator) generateValid() (string, error) {
+	for attempt := 0; attempt < g.maxAttempts; attempt++ {
+		password, err := g.generateOne()
+		if err != nil {
+			return "", err
+		}
+		if !g.satisfiesRules(password) {
+			continue
+		}
+		if full := g.withAffixes(password); g.accept == nil || g.accept(full) {
+			return full, nil
+		}
+	}
+
+	return "", errorf(ErrCharsetExhausted, msg(msgRulesAttemptsExhausted), g.maxAttempts)
+}
+
+// MaxUnique возвращает теоретическое число различных паролей для конфигурации:
+// число размещений без повторений или степень при разрешённых повторах.
+// Для диапазона длин значения суммируются. Дополнительные правила
+// (обязательные наборы, ограничения серий) не учитываются, поэтому
+// результат является верхней оценкой.
+func (g *Generator) MaxUnique() *big.Int {
+	minLength, maxLength := g.length, g.length
+	if g.length == 0 {
+		minLength, maxLength = g.minLength, g.maxLength
+	}
+
+	total := new(big.Int)
+	for length := minLength; length <= maxLength; length++ {
+		total.Add(total, countPasswords(len(g.charset), length, g.allowRepeats))
+	}
+	return total
+}
+
+// Remaining возвращает, сколько ещё уникальных паролей можно получить от
+// генератора: MaxUnique минус уже выданные пароли, но не меньше нуля. Как
+// и MaxUnique, это верхняя оценка; пароли из внешнего хранилища (Config.Store)
+// не вычитаются.
+func (g *Generator) Remaining() *big.Int {
+	remaining := g.MaxUnique()
+	remaining.Sub(remaining, big.NewInt(int64(len(g.used))))
+	if remaining.Sign() < 0 {
+		remaining.SetInt64(0)
+	}
+	return remaining
+}
+
+// countPasswords возвращает число паролей длины length из n символов
+func countPasswords(n, length int, allowRepeats bool) *big.Int {
+	if allowRepeats {
+		return new(big.Int).Exp(big.NewInt(int64(n)), big.NewInt(int64(length)), nil)
+	}
+
+	if length > n {
+		return new(big.Int)
+	}
+
+	// P(n, length) = n * (n-1) * ... * (n-length+1)
+	return new(big.Int).MulRange(int64(n-length+1), int64(n))
+}
+
 // secureRandomInt генерирует безопасное случайное число в диапазоне [0, max)
+//
+// Распределение строго равномерное: rand.Int читает ровно столько бит,
+// сколько нужно для max-1, и отбрасывает значения >= max вместо взятия
+// остатка, поэтому смещения по модулю нет. Ускорения через rand.Read и
+// остаток от деления вносят такое смещение - см. TestSecureRandomIntUniform.
 func secureRandomInt(max int) (int, error) {
+	return randomIntFrom(rand.Reader, max)
+}
+
+// randomIntFrom генерирует равномерное случайное число в диапазоне [0, max)
+// из байт источника r. Ошибка чтения повторяется до RandomRetries раз.
+func randomIntFrom(r io.Reader, max int) (int, error) {
 	if max <= 0 {
-		return 0, fmt.Errorf("максимум должен быть положительным числом")
+		return 0, errors.New(msg(msgRandomMaxNotPositive))
 	}
 
-	nBig, err := rand.Int(rand.Reader, big.NewInt(int64(max)))
-	if err != nil {
-		return 0, fmt.Errorf("ошибка генерации случайного числа: %w", err)
+	var err error
+	for attempt := 0; attempt <= RandomRetries; attempt++ {
+		var nBig *big.Int
+		if nBig, err = rand.Int(r, big.NewInt(int64(max))); err == nil {
+			return int(nBig.Int64()), nil
+		}
 	}
 
-	return int(nBig.Int64()), nil
+	return 0, errorf(ErrRandom, msg(msgRandomFailed), RandomRetries+1, err)
 }
 
-// shuffle перемешивает срез с использованием алгоритма Fisher-Yates и crypto/rand
-func shuffle(slice []rune) error {
+// readRandom заполняет buf байтами из r, повторяя неудачное чтение до
+// RandomRetries раз
+func readRandom(r io.Reader, buf []byte) error {
+	var err error
+	for attempt := 0; attempt <= RandomRetries; attempt++ {
+		if _, err = io.ReadFull(r, buf); err == nil {
+			return nil
+		}
+	}
+
+	return errorf(ErrRandom, msg(msgRandomFailed), RandomRetries+1, err)
+}
+
+// randomInt возвращает случайное число в диапазоне [0, max) из источника генератора
+func (g *Generator) randomInt(max int) (int, error) {
+	if g.rng != nil {
+		return g.rng.Intn(max), nil
+	}
+	return randomIntFrom(g.reader(), max)
+}
+
+// reader возвращает источник случайных байт генератора
+func (g *Generator) reader() io.Reader {
+	if g.random != nil {
+		return g.random
+	}
+	return rand.Reader
+}
+
+// shuffle перемешивает срез с использованием алгоритма Fisher-Yates и источника randInt
+func shuffle[T any](slice []T, randInt func(int) (int, error)) error {
 	for i := len(slice) - 1; i > 0; i-- {
-		j, err := secureRandomInt(i + 1)
+		j, err := randInt(i + 1)
 		if err != nil {
 			return err
 		}
@@ -213,17 +963,17 @@ func shuffle(slice []rune) error {
 	return nil
 }
 
-// removeAtIndex удаляет элемент по индексу из среза
-func removeAtIndex(slice []rune, index int) []rune {
-	return append(slice[:index], slice[index+1:]...)
+// indexRune возвращает индекс руны в срезе или -1, если её нет
+func indexRune(slice []rune, target rune) int {
+	for i, r := range slice {
+		if r == target {
+			return i
+		}
+	}
+	return -1
 }
 
 // containsRune проверяет, содержит ли срез заданную руну
 func containsRune(slice []rune, target rune) bool {
-	for _, r := range slice {
-		if r == target {
-			return true
-		}
-	}
-	return false
+	return indexRune(slice, target) >= 0
 }
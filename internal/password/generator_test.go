@@ -1,8 +1,12 @@
 package password
 
 import (
+	"bytes"
+	"errors"
+	"slices"
 	"strings"
 	"testing"
+	"unicode/utf8"
 )
 
 func TestValidateConfig(t *testing.T) {
@@ -85,11 +89,29 @@ func TestBuildCharset(t *testing.T) {
 			wantLen:      36,
 			wantCharsets: 2,
 		},
+		{
+			name:         "только symbols",
+			config:       Config{UseSymbols: true},
+			wantLen:      len(symbols),
+			wantCharsets: 1,
+		},
+		{
+			name:         "исключение символов",
+			config:       Config{UseDigits: true, UseLower: true, ExcludeChars: "0o1l"},
+			wantLen:      32,
+			wantCharsets: 2,
+		},
+		{
+			name:         "исключение всей группы",
+			config:       Config{UseDigits: true, UseLower: true, ExcludeChars: digits},
+			wantLen:      26,
+			wantCharsets: 1,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			charset, charsets := buildCharset(tt.config)
+			charset, charsets, _ := buildCharset(tt.config)
 			if len(charset) != tt.wantLen {
 				t.Errorf("buildCharset() charset length = %v, want %v", len(charset), tt.wantLen)
 			}
@@ -150,8 +172,8 @@ func TestGenerateSinglePassword(t *testing.T) {
 	}
 
 	// Проверяем длину
-	if len(password) != config.Length {
-		t.Errorf("Password length = %d, want %d", len(password), config.Length)
+	if utf8.RuneCountInString(password) != config.Length {
+		t.Errorf("Password length = %d, want %d", utf8.RuneCountInString(password), config.Length)
 	}
 
 	// Проверяем отсутствие повторов
@@ -361,3 +383,946 @@ func TestNoRepeatedCharactersInPassword(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerateLengthRange(t *testing.T) {
+	config := Config{
+		MinLength: 8,
+		MaxLength: 16,
+		UseDigits: true,
+		UseLower:  true,
+		UseUpper:  true,
+	}
+
+	gen, err := NewGenerator(config)
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	passwords, err := gen.GenerateUnique(200)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+
+	lengths := make(map[int]bool)
+	for _, password := range passwords {
+		n := utf8.RuneCountInString(password)
+		if n < config.MinLength || n > config.MaxLength {
+			t.Errorf("Password %q length = %d, want in [%d, %d]", password, n, config.MinLength, config.MaxLength)
+		}
+		lengths[n] = true
+	}
+
+	if len(lengths) < 2 {
+		t.Errorf("Expected password lengths to vary, got only %v", lengths)
+	}
+}
+
+func TestLengthRangeValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{
+			name:    "максимум меньше минимума",
+			config:  Config{MinLength: 10, MaxLength: 5, UseLower: true},
+			wantErr: true,
+		},
+		{
+			name:    "только максимум",
+			config:  Config{MaxLength: 5, UseLower: true},
+			wantErr: true,
+		},
+		{
+			name:    "максимум больше charset",
+			config:  Config{MinLength: 5, MaxLength: 11, UseDigits: true},
+			wantErr: true,
+		},
+		{
+			name:    "максимум больше charset с повторами",
+			config:  Config{MinLength: 5, MaxLength: 11, UseDigits: true, AllowRepeats: true},
+			wantErr: false,
+		},
+		{
+			name:    "фиксированный диапазон",
+			config:  Config{MinLength: 6, MaxLength: 6, UseDigits: true},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewGenerator(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewGenerator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRemaining(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 3, UseDigits: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	if got := gen.Remaining(); got.Int64() != 720 {
+		t.Fatalf("Remaining() before generation = %s, want 720", got)
+	}
+
+	for _, step := range []int{1, 9, 90} {
+		before := gen.Remaining().Int64()
+		if _, err := gen.GenerateUnique(step); err != nil {
+			t.Fatalf("GenerateUnique(%d) failed: %v", step, err)
+		}
+		if got := gen.Remaining().Int64(); got != before-int64(step) {
+			t.Errorf("Remaining() after %d passwords = %d, want %d", step, got, before-int64(step))
+		}
+	}
+
+	// MaxUnique от выдачи не зависит
+	if got := gen.MaxUnique(); got.Int64() != 720 {
+		t.Errorf("MaxUnique() = %s, want 720", got)
+	}
+}
+
+func TestMaxUnique(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		want   int64
+	}{
+		{
+			name:   "digits длина 3",
+			config: Config{Length: 3, UseDigits: true},
+			want:   720,
+		},
+		{
+			name:   "digits длина 3 с повторами",
+			config: Config{Length: 3, UseDigits: true, AllowRepeats: true},
+			want:   1000,
+		},
+		{
+			name:   "digits все символы",
+			config: Config{Length: 10, UseDigits: true},
+			want:   3628800,
+		},
+		{
+			name:   "диапазон длин",
+			config: Config{MinLength: 1, MaxLength: 2, UseDigits: true},
+			want:   10 + 90,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen, err := NewGenerator(tt.config)
+			if err != nil {
+				t.Fatalf("NewGenerator() failed: %v", err)
+			}
+			if got := gen.MaxUnique(); got.Int64() != tt.want {
+				t.Errorf("MaxUnique() = %s, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// Длинные пароли дают числа далеко за пределами int64: 62^40 ~ 4.9e71
+func TestMaxUniqueLarge(t *testing.T) {
+	alphanumeric := Config{Length: 40, UseDigits: true, UseLower: true, UseUpper: true}
+	withRepeats := alphanumeric
+	withRepeats.AllowRepeats = true
+	lengthRange := Config{MinLength: 39, MaxLength: 40, UseDigits: true, UseLower: true, UseUpper: true}
+
+	tests := []struct {
+		name   string
+		config Config
+		want   string
+	}{
+		{
+			name:   "62 символа длина 40 без повторов",
+			config: alphanumeric,
+			want:   "27998178722366910644469738728147055482653347327026135040000000000",
+		},
+		{
+			name:   "62 символа длина 40 с повторами",
+			config: withRepeats,
+			want:   "496212362459367066914366580195701544604991251555593230875525121862270976",
+		},
+		{
+			name:   "62 символа длины от 39 до 40",
+			config: lengthRange,
+			want:   "29215490840730689368142336064153449199290449384722923520000000000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen, err := NewGenerator(tt.config)
+			if err != nil {
+				t.Fatalf("NewGenerator() failed: %v", err)
+			}
+			got := gen.MaxUnique()
+			if got.String() != tt.want {
+				t.Errorf("MaxUnique() = %s, want %s", got, tt.want)
+			}
+			if got.IsInt64() {
+				t.Errorf("MaxUnique() = %s fits in int64, want a larger value", got)
+			}
+		})
+	}
+}
+
+func TestGenerateUniqueExceedsMaxUnique(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 3, UseDigits: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	_, err = gen.GenerateUnique(5000)
+	if err == nil {
+		t.Fatal("Expected error when count exceeds MaxUnique, got none")
+	}
+	if !strings.Contains(err.Error(), "5000") || !strings.Contains(err.Error(), "720") {
+		t.Errorf("Error %q should mention requested and possible counts", err)
+	}
+
+	// Ошибка возникает до генерации - used остаётся пустым
+	if len(gen.used) != 0 {
+		t.Errorf("used has %d entries, want 0", len(gen.used))
+	}
+}
+
+func TestGenerateCustomUnicodeCharset(t *testing.T) {
+	cyrillic := "абвгдеёжзийклмнопрстуфхцчшщъыьэюя"
+	config := Config{
+		Length:      20,
+		CustomChars: cyrillic,
+	}
+
+	gen, err := NewGenerator(config)
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	passwords, err := gen.GenerateUnique(50)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+
+	for _, password := range passwords {
+		if n := utf8.RuneCountInString(password); n != config.Length {
+			t.Errorf("Password %q rune length = %d, want %d", password, n, config.Length)
+		}
+
+		seen := make(map[rune]bool)
+		for _, char := range password {
+			if !strings.ContainsRune(cyrillic, char) {
+				t.Errorf("Password %q contains invalid character %c", password, char)
+			}
+			if seen[char] {
+				t.Errorf("Password %q has repeated character %c", password, char)
+			}
+			seen[char] = true
+		}
+	}
+}
+
+func TestCustomCharsDeduplication(t *testing.T) {
+	// Дубликаты внутри набора и пересечения с lower не должны давать повторов
+	charset, charsets, _ := buildCharset(Config{UseLower: true, CustomChars: "aab🙂🙂ж"})
+	if len(charset) != 28 {
+		t.Errorf("buildCharset() charset length = %d, want 28", len(charset))
+	}
+	if len(charsets) != 2 {
+		t.Errorf("buildCharset() charsets count = %d, want 2", len(charsets))
+	}
+
+	gen, err := NewGenerator(Config{Length: 4, CustomChars: "🙂🙂жжab"})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+	password, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	if n := utf8.RuneCountInString(password); n != 4 {
+		t.Errorf("Password %q rune length = %d, want 4", password, n)
+	}
+}
+
+func TestClone(t *testing.T) {
+	config := Config{
+		Length:    3,
+		UseDigits: true,
+	}
+
+	gen, err := NewGenerator(config)
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	parentPasswords, err := gen.GenerateUnique(700)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+
+	clone := gen.Clone()
+	if len(clone.used) != 0 {
+		t.Errorf("clone used has %d entries, want 0", len(clone.used))
+	}
+
+	// Клон может выдать почти все 720 комбинаций, несмотря на заполненный used родителя
+	clonePasswords, err := clone.GenerateUnique(100)
+	if err != nil {
+		t.Fatalf("clone GenerateUnique() failed: %v", err)
+	}
+
+	for _, pwd := range clonePasswords {
+		if utf8.RuneCountInString(pwd) != config.Length {
+			t.Errorf("clone password %q length = %d, want %d", pwd, utf8.RuneCountInString(pwd), config.Length)
+		}
+		for _, char := range pwd {
+			if !strings.ContainsRune(digits, char) {
+				t.Errorf("clone password %q contains invalid character %c", pwd, char)
+			}
+		}
+	}
+
+	if len(gen.used) != len(parentPasswords) {
+		t.Errorf("parent used has %d entries after clone generation, want %d", len(gen.used), len(parentPasswords))
+	}
+}
+
+func TestCloneSeeded(t *testing.T) {
+	config := Config{Length: 12, UseLower: true, UseDigits: true}
+
+	newPair := func() (*Generator, *Generator) {
+		gen, err := NewSeededGenerator(config, 7)
+		if err != nil {
+			t.Fatalf("NewSeededGenerator() failed: %v", err)
+		}
+		return gen, gen.Clone()
+	}
+
+	// Клон не продвигает источник родителя: пароли родителя не зависят от
+	// того, генерирует ли клон
+	gen, clone := newPair()
+	if clone.rng == gen.rng {
+		t.Fatal("clone shares the parent's rng")
+	}
+	if _, err := clone.GenerateUnique(5); err != nil {
+		t.Fatalf("clone GenerateUnique() failed: %v", err)
+	}
+	withClone, err := gen.GenerateUnique(5)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+
+	gen, _ = newPair()
+	alone, err := gen.GenerateUnique(5)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+	if !slices.Equal(withClone, alone) {
+		t.Errorf("parent passwords %v depend on clone use, want %v", withClone, alone)
+	}
+
+	// Клон с тем же зерном родителя детерминирован
+	_, first := newPair()
+	_, second := newPair()
+	a, _ := first.GenerateUnique(5)
+	b, _ := second.GenerateUnique(5)
+	if !slices.Equal(a, b) {
+		t.Errorf("clones of equally seeded generators differ: %v and %v", a, b)
+	}
+}
+
+func TestCloneSharesReader(t *testing.T) {
+	// Источник из NewGeneratorWithReader общий: клон читает продолжение
+	// потока родителя
+	stream := make([]byte, 64)
+	for i := range stream {
+		stream[i] = byte(i)
+	}
+	gen, err := NewGeneratorWithReader(Config{Length: 4, CustomChars: "abcdefgh", AllowRepeats: true}, DeterministicReader(stream))
+	if err != nil {
+		t.Fatalf("NewGeneratorWithReader() failed: %v", err)
+	}
+	clone := gen.Clone()
+	if clone.random != gen.random {
+		t.Fatal("clone does not share the parent's reader")
+	}
+
+	reader := gen.random.(*bytes.Reader)
+	if _, err := gen.Generate(); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	left := reader.Len()
+	if _, err := clone.Generate(); err != nil {
+		t.Fatalf("clone Generate() failed: %v", err)
+	}
+	if reader.Len() >= left {
+		t.Errorf("clone did not read from the shared reader: %d bytes left, was %d", reader.Len(), left)
+	}
+}
+
+func TestConfigMaxAttempts(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		want   int
+	}{
+		// 720 комбинаций: 7200 попыток меньше нижней границы
+		{name: "малое пространство", config: Config{Length: 3, UseDigits: true}, want: defaultMaxAttempts},
+		// 30240 комбинаций по 10 попыток
+		{name: "среднее пространство", config: Config{Length: 5, UseDigits: true}, want: 302400},
+		// 100000 комбинаций: ровно верхняя граница
+		{name: "граница малого пространства", config: Config{Length: 5, UseDigits: true, AllowRepeats: true}, want: maxDefaultAttempts},
+		// 151200 комбинаций: 1512000 попыток ограничены верхней границей
+		{name: "большое пространство", config: Config{Length: 6, UseDigits: true}, want: maxDefaultAttempts},
+		{name: "огромное пространство", config: Config{Length: 16, UseLower: true, UseUpper: true}, want: maxDefaultAttempts},
+		{name: "явный лимит", config: Config{Length: 16, UseLower: true, MaxAttempts: 50}, want: 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen, err := NewGenerator(tt.config)
+			if err != nil {
+				t.Fatalf("NewGenerator() failed: %v", err)
+			}
+			if gen.maxAttempts != tt.want {
+				t.Errorf("maxAttempts = %d, want %d", gen.maxAttempts, tt.want)
+			}
+		})
+	}
+
+	if _, err := NewGenerator(Config{Length: 5, UseDigits: true, MaxAttempts: -1}); err == nil {
+		t.Error("Expected error for negative MaxAttempts, got none")
+	}
+}
+
+func TestConfigMaxAttemptsExhaustion(t *testing.T) {
+	// Длина 1 из цифр: ровно 10 уникальных паролей
+	gen, err := NewGenerator(Config{Length: 1, UseDigits: true, MaxAttempts: 500})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	if _, err := gen.GenerateUnique(10); err != nil {
+		t.Fatalf("GenerateUnique(10) failed: %v", err)
+	}
+
+	// Одиннадцатый пароль невозможен - ошибка после MaxAttempts попыток
+	attemptsBefore := gen.attempts
+	_, err = gen.Generate()
+	if err == nil {
+		t.Fatal("Expected exhaustion error, got none")
+	}
+	if !strings.Contains(err.Error(), "500 попыток") {
+		t.Errorf("Error %q should mention the configured limit", err)
+	}
+	if spent := gen.attempts - attemptsBefore; spent != 500 {
+		t.Errorf("spent %d attempts, want 500", spent)
+	}
+}
+
+// largeAlphabet возвращает n различных CJK-символов для нагрузочных тестов
+func largeAlphabet(n int) string {
+	runes := make([]rune, n)
+	for i := range runes {
+		runes[i] = rune(0x4E00 + i)
+	}
+	return string(runes)
+}
+
+func BenchmarkGenerateOneDefault(b *testing.B) {
+	gen, err := NewGenerator(Config{Length: 16, UseDigits: true, UseLower: true, UseUpper: true, UseSymbols: true})
+	if err != nil {
+		b.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	for i := 0; i < b.N; i++ {
+		if _, err := gen.generateOne(); err != nil {
+			b.Fatalf("generateOne() failed: %v", err)
+		}
+	}
+}
+
+func BenchmarkGenerateOneLargeAlphabet(b *testing.B) {
+	gen, err := NewGenerator(Config{Length: 2000, UseDigits: true, CustomChars: largeAlphabet(5000)})
+	if err != nil {
+		b.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	for i := 0; i < b.N; i++ {
+		if _, err := gen.generateOne(); err != nil {
+			b.Fatalf("generateOne() failed: %v", err)
+		}
+	}
+}
+
+func BenchmarkGenerateOneSingleGroup(b *testing.B) {
+	gen, err := NewGenerator(Config{Length: 20, UseLower: true})
+	if err != nil {
+		b.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	for i := 0; i < b.N; i++ {
+		if _, err := gen.generateOne(); err != nil {
+			b.Fatalf("generateOne() failed: %v", err)
+		}
+	}
+}
+
+func BenchmarkGenerateOneSingleGroupShuffled(b *testing.B) {
+	gen, err := NewGenerator(Config{Length: 20, UseLower: true})
+	if err != nil {
+		b.Fatalf("NewGenerator() failed: %v", err)
+	}
+	gen.skipShuffle = false
+
+	for i := 0; i < b.N; i++ {
+		if _, err := gen.generateOne(); err != nil {
+			b.Fatalf("generateOne() failed: %v", err)
+		}
+	}
+}
+
+func TestSkipShuffle(t *testing.T) {
+	noEachSet := false
+	tests := []struct {
+		name   string
+		config Config
+		want   bool
+	}{
+		{"один набор", Config{Length: 8, UseLower: true}, true},
+		{"несколько наборов без обязательных символов", Config{Length: 8, UseDigits: true, UseLower: true, RequireEachSet: &noEachSet}, false},
+		{"несколько наборов", Config{Length: 8, UseDigits: true, UseLower: true}, false},
+		{"предел вхождений", Config{Length: 8, UseLower: true, MaxCharOccurrences: 2}, false},
+		{"веса", Config{Length: 8, UseLower: true, Weights: map[string]int{"lower": 1}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen, err := NewGenerator(tt.config)
+			if err != nil {
+				t.Fatalf("NewGenerator() failed: %v", err)
+			}
+			if gen.skipShuffle != tt.want {
+				t.Errorf("skipShuffle = %v, want %v", gen.skipShuffle, tt.want)
+			}
+		})
+	}
+}
+
+// Без перемешивания все пароли одного набора по-прежнему равновероятны:
+// частоты всех размещений проверяются критерием хи-квадрат
+func TestSkipShuffleDistribution(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   Config
+		outcomes int
+		critical float64 // хи-квадрат для p = 0.001 при outcomes-1 степенях свободы
+	}{
+		{"без повторов", Config{Length: 3, CustomChars: "abcd"}, 24, 49.73},
+		{"с повторами", Config{Length: 2, CustomChars: "abcd", AllowRepeats: true}, 16, 37.70},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Перемешивание пропускается только с crypto/rand
+			gen, err := NewGenerator(tt.config)
+			if err != nil {
+				t.Fatalf("NewGenerator() failed: %v", err)
+			}
+			if !gen.skipShuffle {
+				t.Fatal("skipShuffle = false, want true for a single group")
+			}
+
+			const perOutcome = 1000
+			counts := make(map[string]int)
+			for i := 0; i < tt.outcomes*perOutcome; i++ {
+				pwd, err := gen.generateOne()
+				if err != nil {
+					t.Fatalf("generateOne() failed: %v", err)
+				}
+				counts[pwd]++
+			}
+
+			if len(counts) != tt.outcomes {
+				t.Fatalf("got %d distinct passwords, want %d", len(counts), tt.outcomes)
+			}
+			chi := 0.0
+			for _, c := range counts {
+				d := float64(c - perOutcome)
+				chi += d * d / perOutcome
+			}
+			if chi > tt.critical {
+				t.Errorf("distribution is not uniform: chi-square = %.2f, counts = %v", chi, counts)
+			}
+		})
+	}
+}
+
+func TestGenerateMany(t *testing.T) {
+	// Цифры длины 1: всего 10 вариантов, но 1000 паролей без уникальности возможны
+	gen, err := NewGenerator(Config{Length: 1, UseDigits: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	passwords, err := gen.GenerateMany(1000)
+	if err != nil {
+		t.Fatalf("GenerateMany() failed: %v", err)
+	}
+
+	if len(passwords) != 1000 {
+		t.Errorf("GenerateMany() returned %d passwords, want 1000", len(passwords))
+	}
+	for _, pwd := range passwords {
+		if len(pwd) != 1 || !strings.Contains(digits, pwd) {
+			t.Errorf("invalid password %q", pwd)
+		}
+	}
+	if len(gen.used) != 0 {
+		t.Errorf("GenerateMany() touched used map: %d entries", len(gen.used))
+	}
+
+	if _, err := gen.GenerateMany(0); err == nil {
+		t.Error("Expected error for zero count, got none")
+	}
+}
+
+func TestGenerateMinClasses(t *testing.T) {
+	gen, err := NewGenerator(Config{
+		Length:     4,
+		UseDigits:  true,
+		UseLower:   true,
+		UseUpper:   true,
+		UseSymbols: true,
+		MinClasses: 3,
+	})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	passwords, err := gen.GenerateUnique(500)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+
+	fewerThanAll := 0
+	for _, password := range passwords {
+		classes := Analyze(password).Classes()
+		if classes < 3 {
+			t.Errorf("Password %q spans %d classes, want at least 3", password, classes)
+		}
+		if classes < 4 {
+			fewerThanAll++
+		}
+	}
+
+	// Четвёртый набор не обязателен, поэтому часть паролей обходится без него
+	if fewerThanAll == 0 {
+		t.Error("Every password spans all 4 classes, MinClasses should not force every set")
+	}
+}
+
+func TestMinClassesValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{name: "отрицательное значение", config: Config{Length: 8, UseDigits: true, UseLower: true, MinClasses: -1}},
+		{name: "больше числа наборов", config: Config{Length: 8, UseDigits: true, UseLower: true, MinClasses: 3}},
+		{name: "набор исключён целиком", config: Config{Length: 8, UseDigits: true, UseLower: true, ExcludeChars: digits, MinClasses: 2}},
+		{name: "больше длины", config: Config{MinLength: 2, MaxLength: 8, UseDigits: true, UseLower: true, UseUpper: true, MinClasses: 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewGenerator(tt.config); err == nil {
+				t.Error("NewGenerator() expected error, got none")
+			}
+		})
+	}
+}
+
+func TestRequireEachSetDisabled(t *testing.T) {
+	requireEachSet := false
+	gen, err := NewGenerator(Config{
+		Length:         4,
+		UseDigits:      true,
+		UseLower:       true,
+		UseUpper:       true,
+		RequireEachSet: &requireEachSet,
+	})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	passwords, err := gen.GenerateUnique(500)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+
+	// При равномерном выборе из 62 символов цифры отсутствуют примерно в половине паролей
+	withoutDigits := 0
+	for _, password := range passwords {
+		if !strings.ContainsAny(password, digits) {
+			withoutDigits++
+			if err := gen.Validate(password); err != nil {
+				t.Errorf("Validate(%q) = %v, password without a set should be valid", password, err)
+			}
+		}
+	}
+	if withoutDigits == 0 {
+		t.Error("Every password contains a digit, RequireEachSet=false should not force sets")
+	}
+
+	// По умолчанию (nil) каждый набор обязателен
+	gen, err = NewGenerator(Config{Length: 4, UseDigits: true, UseLower: true, UseUpper: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+	passwords, err = gen.GenerateUnique(500)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+	for _, password := range passwords {
+		if Analyze(password).Classes() != 3 {
+			t.Errorf("Password %q misses a set with default RequireEachSet", password)
+		}
+	}
+}
+
+// chiSquareCritical7 - критическое значение хи-квадрат для 6 степеней свободы
+// при уровне значимости 0.0001: равномерный источник превышает его раз в 10000 запусков
+const chiSquareCritical7 = 27.86
+
+// chiSquare вычисляет статистику хи-квадрат для counts относительно
+// равномерного распределения
+func chiSquare(counts []int, total int) float64 {
+	expected := float64(total) / float64(len(counts))
+	var stat float64
+	for _, c := range counts {
+		diff := float64(c) - expected
+		stat += diff * diff / expected
+	}
+	return stat
+}
+
+func TestSecureRandomIntUniform(t *testing.T) {
+	const draws = 700000
+
+	gen, err := NewGenerator(Config{Length: 4, UseDigits: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	sources := []struct {
+		name string
+		draw func(int) (int, error)
+	}{
+		{name: "secureRandomInt", draw: secureRandomInt},
+		{name: "Generator.randomInt", draw: gen.randomInt},
+	}
+
+	for _, src := range sources {
+		t.Run(src.name, func(t *testing.T) {
+			counts := make([]int, 7)
+			for i := 0; i < draws; i++ {
+				v, err := src.draw(7)
+				if err != nil {
+					t.Fatalf("draw(7) failed: %v", err)
+				}
+				if v < 0 || v >= 7 {
+					t.Fatalf("draw(7) = %d, out of range", v)
+				}
+				counts[v]++
+			}
+
+			if stat := chiSquare(counts, draws); stat > chiSquareCritical7 {
+				t.Errorf("chi-square = %.2f > %.2f, distribution is not uniform: %v", stat, chiSquareCritical7, counts)
+			}
+		})
+	}
+}
+
+func TestChiSquareDetectsModuloBias(t *testing.T) {
+	// Байт по модулю 7: значения 0-3 выпадают чаще (37/256 против 36/256).
+	// Тест равномерности должен ловить такое смещение при том же числе попыток
+	const draws = 700000
+
+	counts := make([]int, 7)
+	for i := 0; i < draws; i++ {
+		counts[(i%256)%7]++
+	}
+
+	if stat := chiSquare(counts, draws); stat <= chiSquareCritical7 {
+		t.Errorf("chi-square = %.2f <= %.2f, modulo bias not detected: %v", stat, chiSquareCritical7, counts)
+	}
+}
+
+func TestCharset(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		want   string
+	}{
+		{name: "цифры и строчные", config: Config{Length: 8, UseDigits: true, UseLower: true}, want: digits + lower},
+		{name: "порядок не зависит от наборов", config: Config{Length: 8, UseUpper: true, UseDigits: true}, want: digits + upper},
+		{name: "исключения", config: Config{Length: 4, UseDigits: true, ExcludeChars: "013"}, want: "2456789"},
+		{name: "дубликаты в custom", config: Config{Length: 3, UseDigits: true, CustomChars: "ba19ж"}, want: digits + "abж"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen, err := NewGenerator(tt.config)
+			if err != nil {
+				t.Fatalf("NewGenerator() failed: %v", err)
+			}
+			got := gen.Charset()
+			if got != tt.want {
+				t.Errorf("Charset() = %q, want %q", got, tt.want)
+			}
+			// Повторный вызов даёт тот же результат и не меняет генератор
+			if again := gen.Charset(); again != got {
+				t.Errorf("Charset() second call = %q, want %q", again, got)
+			}
+		})
+	}
+
+	gen, err := NewGenerator(Config{Length: 8, UseDigits: true, UseLower: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+	if got := gen.Charset(); len(got) != 36 {
+		t.Errorf("Charset() for digits+lower has %d characters, want 36", len(got))
+	}
+}
+
+func TestGenerateSafeSymbolsOnly(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 20, UseLower: true, UseSymbols: true, SafeSymbolsOnly: true, AllowRepeats: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	if got, want := gen.Charset(), "+-.=_"+lower; got != want {
+		t.Errorf("Charset() = %q, want %q", got, want)
+	}
+
+	passwords, err := gen.GenerateUnique(300)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+
+	for _, password := range passwords {
+		for _, r := range password {
+			if strings.ContainsRune(symbols, r) && !strings.ContainsRune(safeSymbols, r) {
+				t.Errorf("Password %q contains unsafe symbol %q", password, r)
+			}
+		}
+		// Правило обязательного присутствия наборов действует и для безопасного подмножества
+		if !strings.ContainsAny(password, safeSymbols) {
+			t.Errorf("Password %q has no safe symbol", password)
+		}
+	}
+
+	// Без UseSymbols флаг ни на что не влияет
+	gen, err = NewGenerator(Config{Length: 8, UseDigits: true, SafeSymbolsOnly: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+	if got := gen.Charset(); got != digits {
+		t.Errorf("Charset() = %q, want %q", got, digits)
+	}
+}
+
+func TestDefaultMaxAttemptsEnumeratesSmallSpace(t *testing.T) {
+	// 3 цифры без повторов: 720 комбинаций. Последние свободные пароли
+	// находятся только при лимите, соразмерном числу комбинаций.
+	gen, err := NewGenerator(Config{Length: 3, UseDigits: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	passwords, err := gen.GenerateUniqueBestEffort(720)
+	if len(passwords) < 710 {
+		t.Errorf("generated %d of 720 combinations (error: %v), want at least 710", len(passwords), err)
+	}
+}
+
+func TestMaxCharOccurrences(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{name: "несколько наборов", config: Config{Length: 30, UseDigits: true, UseLower: true, MaxCharOccurrences: 2}},
+		{name: "ровно 2 * размер набора", config: Config{Length: 20, UseDigits: true, MaxCharOccurrences: 2}},
+		{name: "с весами", config: Config{Length: 40, UseDigits: true, UseUpper: true, Weights: map[string]int{"digits": 5, "upper": 1}, MaxCharOccurrences: 2}},
+		{name: "диапазон длин", config: Config{MinLength: 10, MaxLength: 20, UseDigits: true, MaxCharOccurrences: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen, err := NewGenerator(tt.config)
+			if err != nil {
+				t.Fatalf("NewGenerator() failed: %v", err)
+			}
+
+			for i := 0; i < 50; i++ {
+				password, err := gen.Generate()
+				if err != nil {
+					t.Fatalf("Generate() failed: %v", err)
+				}
+
+				counts := make(map[rune]int)
+				for _, r := range password {
+					counts[r]++
+					if counts[r] > 2 {
+						t.Fatalf("Password %q contains %q three times", password, r)
+					}
+				}
+				if err := gen.Validate(password); err != nil {
+					t.Errorf("Validate(%q) failed: %v", password, err)
+				}
+			}
+		})
+	}
+}
+
+func TestMaxCharOccurrencesLimits(t *testing.T) {
+	// K = 1 - обычный запрет повторов, даже при AllowRepeats
+	one, err := NewGenerator(Config{Length: 10, UseDigits: true, AllowRepeats: true, MaxCharOccurrences: 1})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+	if one.allowRepeats || one.maxOccur != 0 {
+		t.Errorf("K=1: allowRepeats = %v, maxOccur = %d, want no repeats", one.allowRepeats, one.maxOccur)
+	}
+	if _, err := NewGenerator(Config{Length: 11, UseDigits: true, MaxCharOccurrences: 1}); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("K=1, length 11: error = %v, want ErrInvalidConfig", err)
+	}
+
+	// Длина больше K * размер набора недостижима
+	if _, err := NewGenerator(Config{Length: 21, UseDigits: true, MaxCharOccurrences: 2}); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("K=2, length 21: error = %v, want ErrInvalidConfig", err)
+	}
+	if _, err := NewGenerator(Config{Length: 8, UseDigits: true, MaxCharOccurrences: -1}); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("K=-1: error = %v, want ErrInvalidConfig", err)
+	}
+
+	// Validate отклоняет третье вхождение
+	gen, err := NewGenerator(Config{Length: 6, UseDigits: true, MaxCharOccurrences: 2})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+	if err := gen.Validate("112233"); err != nil {
+		t.Errorf("Validate(112233) failed: %v", err)
+	}
+	if err := gen.Validate("111234"); err == nil {
+		t.Error("Validate(111234) expected error, got none")
+	}
+}
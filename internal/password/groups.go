@@ -0,0 +1,140 @@
+package password
+
+import "slices"
+
+// CustomGroup - дополнительный именованный набор символов с минимальным
+// числом символов в каждом пароле
+type CustomGroup struct {
+	Name  string `json:"name"`  // уникальное имя, используется в Weights и сообщениях об ошибках
+	Chars string `json:"chars"` // символы набора, допускаются любые руны Unicode
+	Min   int    `json:"min"`   // минимум символов набора в пароле (0 - как у встроенных наборов)
+}
+
+// validateCustomGroups проверяет имена и минимумы дополнительных наборов
+func validateCustomGroups(groups []CustomGroup) error {
+	seen := make(map[string]bool, len(groups))
+	for _, group := range groups {
+		if group.Name == "" {
+			return errorf(ErrInvalidConfig, msg(msgCustomGroupNoName))
+		}
+		if seen[group.Name] || slices.Contains(groupNames, group.Name) {
+			return errorf(ErrInvalidConfig, msg(msgCustomGroupDuplicate), group.Name)
+		}
+		seen[group.Name] = true
+
+		if group.Min < 0 {
+			return errorf(ErrInvalidConfig, msg(msgCustomGroupNegativeMin), group.Name)
+		}
+
+		if err := checkSingleCodePoints(group.Name, group.Chars); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkExcludedSets проверяет, что исключения не опустошили ни один из
+// выбранных наборов, когда в пароле должен быть символ из каждого набора.
+// Иначе опустевший набор молча выпал бы из требований.
+func checkExcludedSets(config Config, requireEachSet bool) error {
+	if !requireEachSet || config.MinClasses > 0 {
+		return nil
+	}
+
+	var selected []CustomGroup
+	add := func(use bool, name, chars string) {
+		if use {
+			selected = append(selected, CustomGroup{Name: name, Chars: chars})
+		}
+	}
+	add(config.UseDigits, "digits", digits)
+	add(config.UseLower, "lower", lower)
+	add(config.UseUpper, "upper", upper)
+	add(config.UseSymbols && !config.SafeSymbolsOnly, "symbols", symbols)
+	add(config.UseSymbols && config.SafeSymbolsOnly, "symbols", safeSymbols)
+	add(config.UseEmoji, "emoji", emoji)
+	add(config.CustomChars != "", "custom", config.CustomChars)
+	selected = append(selected, config.CustomGroups...)
+
+	// С одним набором требование не действует, а пустой набор отклоняется
+	// как msgCharsetEmpty
+	if len(selected) < 2 {
+		return nil
+	}
+
+	exclude := normalizeChars(config.ExcludeChars)
+	for _, set := range selected {
+		if len(excludeRunes([]rune(normalizeChars(set.Chars)), exclude)) == 0 {
+			return errorf(ErrInvalidConfig, msg(msgRequiredSetExcluded), set.Name)
+		}
+	}
+	return nil
+}
+
+// groupMinimums сопоставляет минимумы дополнительных наборов группам charsets.
+// Возвращает nil, если ни у одного набора нет минимума.
+func groupMinimums(groups []CustomGroup, charsets [][]rune, names []string, allowRepeats bool) ([]int, error) {
+	var mins []int
+	for _, group := range groups {
+		if group.Min == 0 {
+			continue
+		}
+
+		idx := slices.Index(names, group.Name)
+		if idx < 0 {
+			return nil, errorf(ErrInvalidConfig, msg(msgCustomGroupEmpty), group.Name, group.Min)
+		}
+		if !allowRepeats && group.Min > len(charsets[idx]) {
+			return nil, errorf(ErrInvalidConfig, msg(msgCustomGroupMinExceedsChars), group.Name, group.Min, len(charsets[idx]))
+		}
+
+		if mins == nil {
+			mins = make([]int, len(charsets))
+		}
+		mins[idx] = group.Min
+	}
+	return mins, nil
+}
+
+// pickAvailable выбирает случайный символ group среди ещё доступных символов
+// available и возвращает его индекс в available
+func (g *Generator) pickAvailable(group, available []rune) (int, error) {
+	var candidates []int
+	for i, r := range available {
+		if containsRune(group, r) {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) == 0 {
+		return 0, errorf(ErrCharsetExhausted, msg(msgNotEnoughForRequired))
+	}
+
+	idx, err := g.randomInt(len(candidates))
+	if err != nil {
+		return 0, err
+	}
+	return candidates[idx], nil
+}
+
+// requiredCount возвращает, сколько символов пароля в худшем случае заняты
+// обязательными наборами: по одному из каждого набора (или из minClasses
+// наборов) плюс минимумы дополнительных наборов
+func requiredCount(groups int, mins []int, requireEachSet bool, minClasses int) int {
+	total := 0
+	for i := 0; i < groups; i++ {
+		groupMin := 0
+		if mins != nil {
+			groupMin = mins[i]
+		}
+
+		switch {
+		case minClasses > 0:
+			total += groupMin
+		case requireEachSet && groups > 1:
+			total += max(groupMin, 1)
+		default:
+			total += groupMin
+		}
+	}
+	return total + minClasses
+}
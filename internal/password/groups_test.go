@@ -0,0 +1,191 @@
+package password
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestGenerateCustomGroups(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{
+			name: "без повторов",
+			config: Config{
+				Length:   12,
+				UseLower: true,
+				CustomGroups: []CustomGroup{
+					{Name: "greek", Chars: "αβγδεζ", Min: 3},
+					{Name: "marks", Chars: "~|", Min: 2},
+				},
+			},
+		},
+		{
+			name: "с повторами и весами",
+			config: Config{
+				Length:       10,
+				UseDigits:    true,
+				AllowRepeats: true,
+				Weights:      map[string]int{"digits": 1},
+				CustomGroups: []CustomGroup{
+					{Name: "greek", Chars: "αβγ", Min: 4},
+					{Name: "marks", Chars: "~|", Min: 1},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen, err := NewGenerator(tt.config)
+			if err != nil {
+				t.Fatalf("NewGenerator() failed: %v", err)
+			}
+
+			passwords, err := gen.GenerateUnique(200)
+			if err != nil {
+				t.Fatalf("GenerateUnique() failed: %v", err)
+			}
+
+			for _, password := range passwords {
+				if got := utf8.RuneCountInString(password); got != tt.config.Length {
+					t.Errorf("Password %q length = %d, want %d", password, got, tt.config.Length)
+				}
+				for _, group := range tt.config.CustomGroups {
+					if got := countRunes([]rune(password), []rune(group.Chars)); got < group.Min {
+						t.Errorf("Password %q has %d characters from %s, want at least %d", password, got, group.Name, group.Min)
+					}
+				}
+				if err := gen.Validate(password); err != nil {
+					t.Errorf("Validate(%q) = %v", password, err)
+				}
+			}
+		})
+	}
+}
+
+func TestCustomGroupsValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{
+			name:   "без имени",
+			config: Config{Length: 8, UseLower: true, CustomGroups: []CustomGroup{{Chars: "αβ"}}},
+		},
+		{
+			name:   "повтор имени",
+			config: Config{Length: 8, UseLower: true, CustomGroups: []CustomGroup{{Name: "g", Chars: "αβ"}, {Name: "g", Chars: "γδ"}}},
+		},
+		{
+			name:   "имя встроенного набора",
+			config: Config{Length: 8, UseLower: true, CustomGroups: []CustomGroup{{Name: "digits", Chars: "αβ"}}},
+		},
+		{
+			name:   "отрицательный минимум",
+			config: Config{Length: 8, UseLower: true, CustomGroups: []CustomGroup{{Name: "g", Chars: "αβ", Min: -1}}},
+		},
+		{
+			name:   "набор исключён целиком",
+			config: Config{Length: 8, UseLower: true, ExcludeChars: "αβ", CustomGroups: []CustomGroup{{Name: "g", Chars: "αβ", Min: 1}}},
+		},
+		{
+			name:   "минимум больше набора без повторов",
+			config: Config{Length: 8, UseLower: true, CustomGroups: []CustomGroup{{Name: "g", Chars: "αβ", Min: 3}}},
+		},
+		{
+			name:   "минимумы длиннее пароля",
+			config: Config{Length: 4, UseLower: true, CustomGroups: []CustomGroup{{Name: "g", Chars: "αβγ", Min: 3}, {Name: "h", Chars: "~|", Min: 2}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewGenerator(tt.config); err == nil {
+				t.Error("NewGenerator() expected error, got none")
+			}
+		})
+	}
+}
+
+func TestExcludedSetsValidation(t *testing.T) {
+	noEachSet := false
+
+	tests := []struct {
+		name    string
+		config  Config
+		wantSet string // пусто - конфигурация допустима
+	}{
+		{
+			name:    "исключены все цифры",
+			config:  Config{Length: 8, UseDigits: true, UseLower: true, ExcludeChars: digits},
+			wantSet: "digits",
+		},
+		{
+			name:    "исключены безопасные спецсимволы",
+			config:  Config{Length: 8, UseLower: true, UseSymbols: true, SafeSymbolsOnly: true, ExcludeChars: "-_.+="},
+			wantSet: "symbols",
+		},
+		{
+			name:    "исключён custom в разложенной форме",
+			config:  Config{Length: 4, UseLower: true, CustomChars: "\u00e9", ExcludeChars: "e\u0301"},
+			wantSet: "custom",
+		},
+		{
+			name:    "исключён набор с минимумом",
+			config:  Config{Length: 8, UseUpper: true, ExcludeChars: "αβ", CustomGroups: []CustomGroup{{Name: "greek", Chars: "αβ", Min: 2}}},
+			wantSet: "greek",
+		},
+		{
+			name:   "частичное исключение",
+			config: Config{Length: 8, UseDigits: true, UseLower: true, ExcludeChars: "012345678"},
+		},
+		{
+			name:   "каждый набор не обязателен",
+			config: Config{Length: 8, UseDigits: true, UseLower: true, ExcludeChars: digits, RequireEachSet: &noEachSet},
+		},
+		{
+			name:   "достаточно min-classes наборов",
+			config: Config{Length: 8, UseDigits: true, UseLower: true, UseUpper: true, ExcludeChars: digits, MinClasses: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewGenerator(tt.config)
+			if tt.wantSet == "" {
+				if err != nil {
+					t.Errorf("NewGenerator() failed: %v", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, ErrInvalidConfig) {
+				t.Fatalf("NewGenerator() error = %v, want ErrInvalidConfig", err)
+			}
+			if !strings.Contains(err.Error(), fmt.Sprintf("%q", tt.wantSet)) {
+				t.Errorf("error = %q, want it to name set %q", err, tt.wantSet)
+			}
+		})
+	}
+}
+
+func TestCustomGroupsOnly(t *testing.T) {
+	// Дополнительные наборы сами по себе считаются выбранными наборами символов
+	gen, err := NewGenerator(Config{Length: 4, CustomGroups: []CustomGroup{{Name: "g", Chars: "abcdef", Min: 2}, {Name: "h", Chars: "xyz"}}})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	password, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	if utf8.RuneCountInString(password) != 4 || countRunes([]rune(password), []rune("abcdef")) < 2 || !strings.ContainsAny(password, "xyz") {
+		t.Errorf("Password %q does not satisfy group requirements", password)
+	}
+}
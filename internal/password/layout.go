@@ -0,0 +1,46 @@
+package password
+
+// Class - класс символов одной позиции раскладки для GenerateByLayout
+type Class int
+
+const (
+	Upper  Class = iota // большая буква A-Z
+	Lower               // маленькая буква a-z
+	Digit               // цифра 0-9
+	Symbol              // спецсимвол
+)
+
+// layoutClasses связывает классы раскладки с наборами символов
+var layoutClasses = map[Class]string{
+	Upper:  upper,
+	Lower:  lower,
+	Digit:  digits,
+	Symbol: symbols,
+}
+
+// GenerateByLayout генерирует пароль, в котором символ позиции i берётся из
+// класса layout[i], например []Class{Upper, Lower, Lower, Digit, Symbol}
+// даёт "Kmq7#". Длина пароля равна длине раскладки, повторы символов
+// допускаются. В отличие от GenerateFromPattern, раскладка не содержит
+// постоянных символов.
+func GenerateByLayout(layout []Class) (string, error) {
+	if len(layout) == 0 {
+		return "", errorf(ErrInvalidConfig, msg(msgLayoutEmpty))
+	}
+
+	result := make([]rune, len(layout))
+	for i, class := range layout {
+		set, ok := layoutClasses[class]
+		if !ok {
+			return "", errorf(ErrInvalidConfig, msg(msgLayoutUnknownClass), class, i)
+		}
+
+		idx, err := secureRandomInt(len(set))
+		if err != nil {
+			return "", err
+		}
+		result[i] = rune(set[idx])
+	}
+
+	return string(result), nil
+}
@@ -0,0 +1,59 @@
+package password
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGenerateByLayout(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout []Class
+	}{
+		{name: "один класс", layout: []Class{Digit}},
+		{name: "смешанная раскладка", layout: []Class{Upper, Lower, Lower, Digit, Symbol}},
+		{name: "повторяющиеся классы", layout: []Class{Symbol, Symbol, Digit, Digit, Digit, Digit, Upper}},
+		{name: "длинная раскладка", layout: []Class{Lower, Upper, Digit, Symbol, Lower, Upper, Digit, Symbol, Lower, Upper, Digit, Symbol}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				password, err := GenerateByLayout(tt.layout)
+				if err != nil {
+					t.Fatalf("GenerateByLayout() failed: %v", err)
+				}
+
+				runes := []rune(password)
+				if len(runes) != len(tt.layout) {
+					t.Fatalf("Password %q has length %d, want %d", password, len(runes), len(tt.layout))
+				}
+				for pos, r := range runes {
+					if !strings.ContainsRune(layoutClasses[tt.layout[pos]], r) {
+						t.Errorf("Password %q: %q at position %d is not in class %d", password, r, pos, tt.layout[pos])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateByLayoutErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout []Class
+	}{
+		{name: "пустая раскладка", layout: nil},
+		{name: "неизвестный класс", layout: []Class{Upper, Class(42)}},
+		{name: "отрицательный класс", layout: []Class{Class(-1)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := GenerateByLayout(tt.layout); !errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("GenerateByLayout() error = %v, want ErrInvalidConfig", err)
+			}
+		})
+	}
+}
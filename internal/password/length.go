@@ -0,0 +1,54 @@
+package password
+
+// GenerateLength генерирует один уникальный пароль длины n вместо длины из
+// конфигурации. Набор символов, правила и множество использованных паролей
+// те же, что у Generate, поэтому пароли разной длины не повторяются.
+func (g *Generator) GenerateLength(n int) (string, error) {
+	if err := g.checkLength(n); err != nil {
+		return "", err
+	}
+
+	// Копия делит с g множество used, хранилище и источник случайности,
+	// отличается только длина
+	override := *g
+	override.length = n
+	password, err := override.Generate()
+	g.attempts = override.attempts
+	return password, err
+}
+
+// checkLength проверяет длину n по тем же правилам, что NewGenerator
+// проверяет длину из конфигурации
+func (g *Generator) checkLength(n int) error {
+	if n <= 0 {
+		return errorf(ErrInvalidConfig, msg(msgLengthNotPositive))
+	}
+
+	if g.minClasses > n {
+		return errorf(ErrInvalidConfig, msg(msgLengthBelowMinClasses), n, g.minClasses)
+	}
+
+	if !g.allowRepeats && n > len(g.charset) {
+		return errorf(ErrInvalidConfig, msg(msgLengthExceedsCharset), n, len(g.charset))
+	}
+
+	if limit := g.maxOccur * len(g.charset); limit > 0 && n > limit {
+		return errorf(ErrInvalidConfig, msg(msgLengthExceedsOccurrences), n, limit, g.maxOccur)
+	}
+
+	if required := requiredCount(len(g.charsets), g.groupMins, g.requireEachSet, g.minClasses); required > n {
+		return errorf(ErrInvalidConfig, msg(msgRequiredExceedsLength), n, required)
+	}
+
+	if capacity := weightedCapacity(g.charsets, g.weights, g.requireEachSet); !g.allowRepeats && g.weights != nil && n > capacity {
+		return errorf(ErrInvalidConfig, msg(msgLengthExceedsWeighted), n, capacity)
+	}
+
+	if g.alternateClasses {
+		if err := checkAlternateClasses(g.charsets, n, g.allowRepeats, g.maxOccur); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
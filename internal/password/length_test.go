@@ -0,0 +1,93 @@
+package password
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestGenerateLength(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 12, UseDigits: true, UseLower: true, UseUpper: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	for _, n := range []int{3, 8, 16, 32, 62, 8} {
+		password, err := gen.GenerateLength(n)
+		if err != nil {
+			t.Fatalf("GenerateLength(%d) failed: %v", n, err)
+		}
+		if got := utf8.RuneCountInString(password); got != n {
+			t.Errorf("GenerateLength(%d) = %q with length %d", n, password, got)
+		}
+		if !strings.ContainsAny(password, digits) || !strings.ContainsAny(password, lower) || !strings.ContainsAny(password, upper) {
+			t.Errorf("GenerateLength(%d) = %q does not contain every set", n, password)
+		}
+		if seen[password] {
+			t.Errorf("Duplicate password %q", password)
+		}
+		seen[password] = true
+	}
+
+	// Переопределение не меняет длину из конфигурации
+	password, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	if len(password) != 12 {
+		t.Errorf("Generate() after GenerateLength returned length %d, want 12", len(password))
+	}
+}
+
+func TestGenerateLengthSharesUsed(t *testing.T) {
+	// Одна цифра: всего 10 паролей длины 1 на генератор
+	gen, err := NewGenerator(Config{Length: 4, UseDigits: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		if _, err := gen.GenerateLength(1); err != nil {
+			t.Fatalf("GenerateLength(1) #%d failed: %v", i+1, err)
+		}
+	}
+	if _, err := gen.GenerateLength(1); !errors.Is(err, ErrCharsetExhausted) {
+		t.Errorf("GenerateLength(1) after 10 passwords error = %v, want ErrCharsetExhausted", err)
+	}
+}
+
+func TestGenerateLengthInvalid(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 6, UseDigits: true, UseLower: true, UseUpper: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		n    int
+	}{
+		{name: "ноль", n: 0},
+		{name: "отрицательная", n: -3},
+		{name: "меньше числа обязательных наборов", n: 2},
+		{name: "больше набора без повторов", n: 63},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := gen.GenerateLength(tt.n); !errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("GenerateLength(%d) error = %v, want ErrInvalidConfig", tt.n, err)
+			}
+		})
+	}
+
+	// С повторами длина не ограничена размером набора
+	repeats, err := NewGenerator(Config{Length: 6, UseDigits: true, AllowRepeats: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+	if password, err := repeats.GenerateLength(40); err != nil || len(password) != 40 {
+		t.Errorf("GenerateLength(40) = %q, %v with repeats allowed", password, err)
+	}
+}
@@ -0,0 +1,71 @@
+package password
+
+import (
+	"errors"
+	"regexp"
+)
+
+// GenerateMatching генерирует уникальный пароль, который целиком совпадает
+// с pattern. Длина случайной части берётся из конфигурации: Length, если
+// она не больше maxLen, иначе maxLen; для диапазона MinLength-MaxLength
+// верхняя граница урезается до maxLen. Кандидаты из набора генератора
+// перебираются случайно, проверка идёт после генерации, поэтому число
+// попыток ограничено лимитом генератора (Config.MaxAttempts).
+//
+// Шаблоны, которым соответствует малая доля кандидатов (длинные, с узкими
+// классами символов или символами вне набора генератора), могут не найти
+// совпадения за лимит - тогда возвращается ErrCharsetExhausted с
+// сообщением о несовпадении с шаблоном. Для
+// паролей с жёсткой структурой лучше подходит GenerateFromPattern.
+func (g *Generator) GenerateMatching(pattern *regexp.Regexp, maxLen int) (string, error) {
+	if pattern == nil {
+		return "", errorf(ErrInvalidConfig, msg(msgPatternNil))
+	}
+
+	override := *g
+	switch {
+	case g.length > 0 && g.length <= maxLen:
+		// Длина из конфигурации уже проверена NewGenerator
+	case g.length > 0:
+		if err := g.checkLength(maxLen); err != nil {
+			return "", err
+		}
+		override.length = maxLen
+	case g.minLength > maxLen:
+		return "", errorf(ErrInvalidConfig, msg(msgMatchingMaxLenBelowMin), maxLen, g.minLength)
+	default:
+		override.maxLength = min(g.maxLength, maxLen)
+	}
+
+	// Шаблон проверяется по паролю целиком, вместе с префиксом и суффиксом
+	fullMatch := func(candidate string) bool {
+		loc := pattern.FindStringIndex(candidate)
+		return loc != nil && loc[0] == 0 && loc[1] == len(candidate)
+	}
+	matched := false
+	mismatch := func(candidate string) bool {
+		if !fullMatch(candidate) {
+			return true
+		}
+		matched = true
+		return false
+	}
+	password, err := override.generateAvoiding(mismatch, g.maxAttempts)
+	g.attempts = override.attempts
+	if err == nil || !errors.Is(err, ErrCharsetExhausted) {
+		return password, err
+	}
+
+	// Уже выданные пароли отбрасываются до проверки шаблона: если среди них
+	// есть совпадающие, причина в исчерпании уникальных паролей
+	for used := range g.used {
+		if matched {
+			break
+		}
+		matched = fullMatch(g.withAffixes(used))
+	}
+	if !matched {
+		return "", errorf(ErrCharsetExhausted, msg(msgPatternAttemptsExhausted), g.maxAttempts)
+	}
+	return "", err
+}
@@ -0,0 +1,169 @@
+package password
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestGenerateMatching(t *testing.T) {
+	requireEachSet := false
+
+	tests := []struct {
+		name    string
+		config  Config
+		pattern string
+		maxLen  int
+	}{
+		{
+			name:    "буква, три цифры и две строчные",
+			config:  Config{Length: 6, UseDigits: true, UseLower: true, UseUpper: true},
+			pattern: `^[A-Z]\d{3}[a-z]{2}$`,
+			maxLen:  6,
+		},
+		{
+			name:    "только цифры",
+			config:  Config{Length: 4, UseDigits: true, UseLower: true, RequireEachSet: &requireEachSet},
+			pattern: `^\d{2,4}$`,
+			maxLen:  4,
+		},
+		{
+			name:    "начинается с буквы",
+			config:  Config{Length: 8, UseDigits: true, UseLower: true},
+			pattern: `^[a-z].*\d$`,
+			maxLen:  8,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen, err := NewGenerator(tt.config)
+			if err != nil {
+				t.Fatalf("NewGenerator() failed: %v", err)
+			}
+			re := regexp.MustCompile(tt.pattern)
+
+			seen := make(map[string]bool)
+			for i := 0; i < 5; i++ {
+				password, err := gen.GenerateMatching(re, tt.maxLen)
+				if err != nil {
+					t.Fatalf("GenerateMatching() failed: %v", err)
+				}
+				if !re.MatchString(password) || len([]rune(password)) > tt.maxLen {
+					t.Errorf("GenerateMatching() = %q, does not match %s within %d characters", password, tt.pattern, tt.maxLen)
+				}
+				if seen[password] {
+					t.Errorf("Duplicate password %q", password)
+				}
+				seen[password] = true
+			}
+		})
+	}
+}
+
+func TestGenerateMatchingLength(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   Config
+		maxLen   int
+		min, max int
+	}{
+		{name: "длина из конфигурации", config: Config{Length: 8, UseDigits: true, UseLower: true}, maxLen: 12, min: 8, max: 8},
+		{name: "длина больше maxLen", config: Config{Length: 16, UseDigits: true, UseLower: true}, maxLen: 10, min: 10, max: 10},
+		{name: "диапазон урезается", config: Config{MinLength: 6, MaxLength: 12, UseDigits: true, UseLower: true}, maxLen: 8, min: 6, max: 8},
+	}
+
+	pattern := regexp.MustCompile(`^[a-z0-9]+$`)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen, err := NewGenerator(tt.config)
+			if err != nil {
+				t.Fatalf("NewGenerator() failed: %v", err)
+			}
+
+			for i := 0; i < 50; i++ {
+				password, err := gen.GenerateMatching(pattern, tt.maxLen)
+				if err != nil {
+					t.Fatalf("GenerateMatching() failed: %v", err)
+				}
+				if n := len(password); n < tt.min || n > tt.max {
+					t.Errorf("GenerateMatching() = %q, length %d outside [%d, %d]", password, n, tt.min, tt.max)
+				}
+			}
+		})
+	}
+
+	gen, err := NewGenerator(Config{MinLength: 6, MaxLength: 12, UseDigits: true, UseLower: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+	if _, err := gen.GenerateMatching(pattern, 4); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("GenerateMatching(maxLen below MinLength) error = %v, want ErrInvalidConfig", err)
+	}
+}
+
+func TestGenerateMatchingFullMatch(t *testing.T) {
+	// Неякорный шаблон всё равно должен совпадать со всем паролем
+	gen, err := NewGenerator(Config{MinLength: 1, MaxLength: 3, CustomChars: "ab", AllowRepeats: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		password, err := gen.GenerateMatching(regexp.MustCompile(`a+`), 3)
+		if err != nil {
+			t.Fatalf("GenerateMatching() failed: %v", err)
+		}
+		if password != "a" && password != "aa" && password != "aaa" {
+			t.Errorf("GenerateMatching() = %q, want only a's", password)
+		}
+	}
+}
+
+func TestGenerateMatchingErrors(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 6, UseDigits: true, UseLower: true, MaxAttempts: 500})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	if _, err := gen.GenerateMatching(nil, 6); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("GenerateMatching(nil) error = %v, want ErrInvalidConfig", err)
+	}
+	if _, err := gen.GenerateMatching(regexp.MustCompile(`.`), 0); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("GenerateMatching(maxLen 0) error = %v, want ErrInvalidConfig", err)
+	}
+	// Обязательные цифры и строчные требуют длины не меньше 2
+	if _, err := gen.GenerateMatching(regexp.MustCompile(`.`), 1); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("GenerateMatching(maxLen 1) error = %v, want ErrInvalidConfig", err)
+	}
+	// Прописных букв нет в наборе, совпадение невозможно: ошибка говорит о
+	// шаблоне, а не об исчерпании уникальных паролей
+	_, err = gen.GenerateMatching(regexp.MustCompile(`^[A-Z]+$`), 6)
+	if !errors.Is(err, ErrCharsetExhausted) {
+		t.Errorf("GenerateMatching(impossible) error = %v, want ErrCharsetExhausted", err)
+	}
+	if want := fmt.Sprintf(msg(msgPatternAttemptsExhausted), 500); err == nil || err.Error() != want {
+		t.Errorf("GenerateMatching(impossible) error = %v, want %q", err, want)
+	}
+}
+
+func TestGenerateMatchingExhaustedUnique(t *testing.T) {
+	// Все 10 совпадающих паролей выданы: ошибка говорит об исчерпании
+	// уникальных паролей, а не о шаблоне
+	gen, err := NewGenerator(Config{Length: 1, UseDigits: true, MaxAttempts: 500})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	pattern := regexp.MustCompile(`^[0-9]$`)
+	for i := 0; i < 10; i++ {
+		if _, err := gen.GenerateMatching(pattern, 1); err != nil {
+			t.Fatalf("GenerateMatching() failed: %v", err)
+		}
+	}
+	_, err = gen.GenerateMatching(pattern, 1)
+	if want := fmt.Sprintf(msg(msgUniqueAttemptsExhausted), 500); err == nil || err.Error() != want {
+		t.Errorf("GenerateMatching() error = %v, want %q", err, want)
+	}
+}
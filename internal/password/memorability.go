@@ -0,0 +1,69 @@
+package password
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Пороги, после которых фраза считается труднее для запоминания
+const (
+	memorableMaxWords   = 6  // слов в фразе
+	memorableMaxLetters = 30 // букв во всех словах вместе
+	memorableMaxWordLen = 8  // средняя длина слова
+)
+
+// passphraseSeparators - символы, разделяющие слова фразы и не считающиеся шумом
+const passphraseSeparators = " -_."
+
+// Memorability оценивает по шкале 0-4, насколько легко запомнить фразу:
+// 4 - несколько коротких распространённых слов, 0 - длинная цепочка редких
+// слов с цифрами и символами. Слова сравниваются со словарём wordlist без
+// учёта регистра (nil - все слова считаются редкими). В паре со Strength
+// оценка показывает компромисс между надёжностью и удобством.
+func Memorability(passphrase string, wordlist map[string]bool) int {
+	words := strings.FieldsFunc(strings.ToLower(passphrase), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	if len(words) == 0 {
+		return 0
+	}
+
+	score := 4
+
+	// Количество слов
+	if len(words) > memorableMaxWords {
+		score--
+	}
+
+	// Доля распространённых слов
+	common, letters := 0, 0
+	for _, word := range words {
+		if wordlist[word] {
+			common++
+		}
+		letters += len([]rune(word))
+	}
+	switch {
+	case common*2 < len(words):
+		score -= 2
+	case common < len(words):
+		score--
+	}
+
+	// Общая длина и длина слов
+	if letters > memorableMaxLetters {
+		score--
+	}
+	if letters > memorableMaxWordLen*len(words) {
+		score--
+	}
+
+	// Цифры и символы между словами
+	if strings.ContainsFunc(passphrase, func(r rune) bool {
+		return !unicode.IsLetter(r) && !strings.ContainsRune(passphraseSeparators, r)
+	}) {
+		score--
+	}
+
+	return max(score, 0)
+}
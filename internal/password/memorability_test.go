@@ -0,0 +1,50 @@
+package password
+
+import "testing"
+
+func TestMemorability(t *testing.T) {
+	wordlist := map[string]bool{
+		"correct": true, "horse": true, "battery": true, "staple": true,
+		"red": true, "cat": true, "sun": true, "tree": true,
+	}
+
+	tests := []struct {
+		name       string
+		passphrase string
+		want       int
+	}{
+		{name: "короткие распространённые слова", passphrase: "red cat sun", want: 4},
+		{name: "регистр и дефисы", passphrase: "Correct-Horse-Battery-Staple", want: 4},
+		{name: "одно редкое слово", passphrase: "red cat zephyr", want: 3},
+		{name: "цифры между словами", passphrase: "red7cat!sun", want: 3},
+		{name: "длинная фраза из редких слов", passphrase: "quixotic zephyr obfuscate perspicacious lugubrious sesquipedalian antidisestablishment", want: 0},
+		{name: "редкие слова", passphrase: "quixotic zephyr", want: 2},
+		{name: "пустая строка", passphrase: "", want: 0},
+		{name: "без слов", passphrase: "1234-!!", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Memorability(tt.passphrase, wordlist); got != tt.want {
+				t.Errorf("Memorability(%q) = %d, want %d", tt.passphrase, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemorabilityTradeoff(t *testing.T) {
+	wordlist := map[string]bool{"red": true, "cat": true, "sun": true}
+
+	short := "red cat sun"
+	long := "quixotic-zephyr-obfuscate-perspicacious-lugubrious-sesquipedalian"
+
+	if Memorability(short, wordlist) <= Memorability(long, wordlist) {
+		t.Errorf("Memorability(%q) = %d, want more than Memorability(%q) = %d",
+			short, Memorability(short, wordlist), long, Memorability(long, wordlist))
+	}
+
+	// Без словаря все слова считаются редкими
+	if got := Memorability(short, nil); got != 2 {
+		t.Errorf("Memorability(%q, nil) = %d, want 2", short, got)
+	}
+}
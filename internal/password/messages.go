@@ -0,0 +1,347 @@
+package password
+
+// Lang - язык сообщений об ошибках
+type Lang int
+
+const (
+	Russian Lang = iota
+	English
+)
+
+// Language - язык сообщений об ошибках генератора. Задаётся один раз при
+// старте программы, до создания генераторов: переменная не защищена от
+// одновременного изменения.
+var Language = Russian
+
+// messageID - идентификатор сообщения об ошибке
+type messageID int
+
+const (
+	msgCharsetEmpty messageID = iota
+	msgLeadingDigitOnlyDigits
+	msgMinClassesExceedsSets
+	msgLengthBelowMinClasses
+	msgSymbolAtEndNoSymbols
+	msgLengthExceedsCharset
+	msgLengthExceedsWeighted
+	msgEntropyTooLowSets
+	msgEntropyTooLowLength
+	msgLengthNotPositive
+	msgMinLengthNotPositive
+	msgMaxLengthBelowMin
+	msgNoCharsets
+	msgNegativeMaxConsecutive
+	msgNegativeMaxSequential
+	msgNegativeMinClasses
+	msgNegativeMaxAttempts
+	msgNegativeMinEntropy
+	msgUnknownWeightSet
+	msgNegativeWeight
+	msgUniqueAttemptsExhausted
+	msgNotEnoughForRequired
+	msgNotEnoughUnique
+	msgCountNotPositive
+	msgCountExceedsMaxUnique
+	msgGenerateUniqueFailed
+	msgRulesAttemptsExhausted
+	msgRandomFailed
+	msgNoPositiveWeight
+	msgStoreSaveFailed
+	msgRandomMaxNotPositive
+	msgCustomGroupNoName
+	msgCustomGroupDuplicate
+	msgCustomGroupNegativeMin
+	msgCustomGroupEmpty
+	msgCustomGroupMinExceedsChars
+	msgRequiredExceedsLength
+	msgPatternNil
+	msgGenerateInterrupted
+	msgBestEffortShortfall
+	msgCombiningMark
+	msgMasterEmpty
+	msgRequiredSetExcluded
+	msgLayoutEmpty
+	msgLayoutUnknownClass
+	msgNegativeMaxOccurrences
+	msgLengthExceedsOccurrences
+	msgWorkersNotPositive
+	msgAlternateOneSet
+	msgAlternateLength
+	msgWordlistLineSpaces
+	msgWordlistTooSmall
+	msgWordlistEmpty
+	msgWordsNotPositive
+	msgNthOutOfRange
+	msgBufferBelowAffixes
+	msgBufferNonASCII
+	msgLengthBelowEntropyCount
+	msgPrefixMissing
+	msgSuffixMissing
+	msgLengthMismatch
+	msgLengthOutOfRange
+	msgCharNotInCharset
+	msgCharRepeated
+	msgCharTooFrequent
+	msgTooFewClasses
+	msgMissingSet
+	msgGroupBelowMin
+	msgRulesViolated
+	msgRuleConsecutive
+	msgRuleSequential
+	msgRuleKeyboard
+	msgRuleYear
+	msgRuleLeadingDigit
+	msgRuleSymbolAtEnd
+	msgRuleAdjacentClass
+	msgRuleBlocked
+	msgBytesNotPositive
+	msgUnknownEncoding
+	msgRandomBytesFailed
+	msgSyllablesNotPositive
+	msgPatternEmpty
+	msgPatternTrailingEscape
+	msgPatternInvalidEscape
+	msgCharsetStringEmpty
+	msgProfanityAttemptsExhausted
+	msgStatsExhaustionWarning
+	msgStoreOpenFailed
+	msgStoreReadFailed
+	msgStoreWriteFailed
+	msgConfigReadFailed
+	msgConfigInvalid
+	msgWordlistOpenFailed
+	msgWordlistReadFailed
+	msgCompareConfigFailed
+	msgPatternAttemptsExhausted
+	msgCrackUnderSecond
+	msgCrackYears
+	msgCrackDays
+	msgCrackHours
+	msgCrackMinutes
+	msgCrackSeconds
+	msgMatchingMaxLenBelowMin
+)
+
+// messages содержит шаблоны сообщений для каждого языка. Порядок
+// подстановок в переводах должен совпадать.
+var messages = map[Lang]map[messageID]string{
+	Russian: {
+		msgCharsetEmpty:               "после исключения символов не осталось ни одного доступного символа",
+		msgLeadingDigitOnlyDigits:     "нельзя запретить цифру в начале пароля, если набор состоит только из цифр",
+		msgMinClassesExceedsSets:      "требуется %d разных наборов символов, но доступно только %d",
+		msgLengthBelowMinClasses:      "длина пароля (%d) меньше требуемого числа наборов символов (%d)",
+		msgSymbolAtEndNoSymbols:       "для спецсимвола в конце пароля нужен набор symbols, в котором остались символы",
+		msgLengthExceedsCharset:       "длина пароля (%d) превышает количество доступных уникальных символов (%d)",
+		msgLengthExceedsWeighted:      "длина пароля (%d) превышает количество уникальных символов, доступных с учётом весов (%d)",
+		msgEntropyTooLowSets:          "энтропия конфигурации (%.1f бит) ниже требуемой (%.1f бит): включите больше наборов символов или разрешите повторы",
+		msgEntropyTooLowLength:        "энтропия конфигурации (%.1f бит) ниже требуемой (%.1f бит): увеличьте длину как минимум до %d символов или включите больше наборов символов",
+		msgLengthNotPositive:          "длина пароля должна быть положительным числом",
+		msgMinLengthNotPositive:       "минимальная длина пароля должна быть положительным числом",
+		msgMaxLengthBelowMin:          "максимальная длина пароля (%d) меньше минимальной (%d)",
+		msgNoCharsets:                 "необходимо выбрать хотя бы один набор символов (digits, lower, upper, symbols, emoji или custom)",
+		msgNegativeMaxConsecutive:     "максимальное число одинаковых символов подряд не может быть отрицательным",
+		msgNegativeMaxSequential:      "максимальная длина последовательности не может быть отрицательной",
+		msgNegativeMinClasses:         "минимальное число наборов символов не может быть отрицательным",
+		msgNegativeMaxAttempts:        "лимит попыток не может быть отрицательным",
+		msgNegativeMinEntropy:         "минимальная энтропия не может быть отрицательной",
+		msgUnknownWeightSet:           "неизвестный набор %q в весах (допустимо: digits, lower, upper, symbols, emoji, custom)",
+		msgNegativeWeight:             "вес набора %q не может быть отрицательным",
+		msgUniqueAttemptsExhausted:    "не удалось сгенерировать уникальный пароль за %d попыток, возможно достигнут лимит комбинаций",
+		msgNotEnoughForRequired:       "недостаточно символов для удовлетворения требований",
+		msgNotEnoughUnique:            "недостаточно уникальных символов",
+		msgCountNotPositive:           "количество паролей должно быть положительным числом",
+		msgCountExceedsMaxUnique:      "запрошено %d паролей, но возможно только %s уникальных паролей",
+		msgGenerateUniqueFailed:       "не удалось сгенерировать %d уникальных паролей: %w",
+		msgRulesAttemptsExhausted:     "не удалось сгенерировать пароль, удовлетворяющий правилам, за %d попыток",
+		msgRandomFailed:               "ошибка генерации случайного числа после %d попыток: %w",
+		msgNoPositiveWeight:           "хотя бы один из выбранных наборов должен иметь положительный вес",
+		msgStoreSaveFailed:            "не удалось сохранить пароль в хранилище: %w",
+		msgRandomMaxNotPositive:       "максимум должен быть положительным числом",
+		msgCustomGroupNoName:          "у дополнительного набора символов должно быть имя",
+		msgCustomGroupDuplicate:       "имя дополнительного набора %q уже используется",
+		msgCustomGroupNegativeMin:     "минимум набора %q не может быть отрицательным",
+		msgCustomGroupEmpty:           "в наборе %q не осталось символов, а требуется минимум %d",
+		msgCustomGroupMinExceedsChars: "минимум набора %q (%d) превышает число его символов (%d) без повторов",
+		msgRequiredExceedsLength:      "длина пароля (%d) меньше числа обязательных символов (%d)",
+		msgPatternNil:                 "регулярное выражение не задано",
+		msgGenerateInterrupted:        "генерация прервана после %d из %d паролей: %w",
+		msgBestEffortShortfall:        "удалось сгенерировать только %d из %d уникальных паролей: %w",
+		msgCombiningMark:              "набор %q содержит комбинируемый знак %U, который не образует единый символ после нормализации NFC",
+		msgMasterEmpty:                "мастер-пароль не может быть пустым",
+		msgRequiredSetExcluded:        "все символы набора %q исключены, а пароль должен содержать символ из каждого набора: уберите набор или сократите исключения",
+		msgLayoutEmpty:                "раскладка не может быть пустой",
+		msgLayoutUnknownClass:         "неизвестный класс символов %d в позиции %d раскладки",
+		msgNegativeMaxOccurrences:     "предел вхождений символа не может быть отрицательным",
+		msgLengthExceedsOccurrences:   "длина пароля (%d) превышает %d символов, доступных при пределе %d вхождений каждого символа",
+		msgWorkersNotPositive:         "число горутин должно быть положительным числом",
+		msgAlternateOneSet:            "для чередования наборов нужно хотя бы два набора символов",
+		msgAlternateLength:            "длина %d слишком велика для чередования наборов без повторов, максимум %d",
+		msgWordlistLineSpaces:         "словарь %s, строка %d: слово не должно содержать пробелов",
+		msgWordlistTooSmall:           "в словаре %s %d разных слов, для надёжных фраз нужно не меньше %d",
+		msgWordlistEmpty:              "словарь пуст",
+		msgWordsNotPositive:           "количество слов должно быть положительным числом",
+		msgNthOutOfRange:              "номер пароля %d вне диапазона от 0 до %s (не включая)",
+		msgBufferBelowAffixes:         "буфер длиной %d байт меньше префикса и суффикса (%d байт)",
+		msgBufferNonASCII:             "символ %q занимает больше одного байта, в буфер можно записать только символы ASCII",
+		msgLengthBelowEntropyCount:    "длина %d меньше необходимой: для %.1f бит энтропии и %d уникальных паролей нужно не меньше %d символов",
+		msgPrefixMissing:              "пароль не начинается с префикса %q",
+		msgSuffixMissing:              "пароль не заканчивается суффиксом %q",
+		msgLengthMismatch:             "длина пароля %d, требуется %d",
+		msgLengthOutOfRange:           "длина пароля %d вне диапазона от %d до %d",
+		msgCharNotInCharset:           "символ %q с индексом %d не входит в набор допустимых символов",
+		msgCharRepeated:               "символ %q с индексом %d повторяется, а повторы запрещены",
+		msgCharTooFrequent:            "символ %q с индексом %d встречается больше %d раз",
+		msgTooFewClasses:              "пароль содержит символы из %d наборов, требуется %d",
+		msgMissingSet:                 "пароль не содержит ни одного символа из набора %q",
+		msgGroupBelowMin:              "пароль содержит %d символов из набора %q, требуется не меньше %d",
+		msgRulesViolated:              "пароль нарушает правила: %w",
+		msgRuleConsecutive:            "больше %d одинаковых символов подряд",
+		msgRuleSequential:             "последовательность соседних символов длиннее %d",
+		msgRuleKeyboard:               "пароль содержит клавиатурную серию",
+		msgRuleYear:                   "пароль содержит год %s",
+		msgRuleLeadingDigit:           "пароль начинается с цифры",
+		msgRuleSymbolAtEnd:            "пароль не заканчивается спецсимволом",
+		msgRuleAdjacentClass:          "соседние символы из одного набора",
+		msgRuleBlocked:                "пароль входит в список запрещённых",
+		msgBytesNotPositive:           "количество байт должно быть положительным числом",
+		msgUnknownEncoding:            "неизвестный режим кодирования %q (поддерживаются hex и base64)",
+		msgRandomBytesFailed:          "ошибка генерации случайных байт: %w",
+		msgSyllablesNotPositive:       "количество слогов должно быть положительным числом",
+		msgPatternEmpty:               "шаблон не может быть пустым",
+		msgPatternTrailingEscape:      "шаблон заканчивается незавершённым экранированием",
+		msgPatternInvalidEscape:       "недопустимое экранирование %q в позиции %d",
+		msgCharsetStringEmpty:         "набор символов не может быть пустым",
+		msgProfanityAttemptsExhausted: "не удалось сгенерировать пароль без запрещённых слов за %d попыток",
+		msgStatsExhaustionWarning:     "использовано более половины возможных комбинаций, увеличьте длину или набор символов",
+		msgStoreOpenFailed:            "не удалось открыть хранилище паролей: %w",
+		msgStoreReadFailed:            "не удалось прочитать хранилище паролей: %w",
+		msgStoreWriteFailed:           "не удалось записать пароль в хранилище: %w",
+		msgConfigReadFailed:           "не удалось прочитать файл конфигурации: %w",
+		msgConfigInvalid:              "некорректный файл конфигурации %s: %w",
+		msgWordlistOpenFailed:         "не удалось открыть словарь: %w",
+		msgWordlistReadFailed:         "не удалось прочитать словарь: %w",
+		msgCompareConfigFailed:        "конфигурация %d: %w",
+		msgPatternAttemptsExhausted:   "не удалось сгенерировать пароль, совпадающий с шаблоном, за %d попыток",
+		msgCrackUnderSecond:           "меньше секунды",
+		msgCrackYears:                 "год|года|лет",
+		msgCrackDays:                  "день|дня|дней",
+		msgCrackHours:                 "час|часа|часов",
+		msgCrackMinutes:               "минута|минуты|минут",
+		msgCrackSeconds:               "секунда|секунды|секунд",
+		msgMatchingMaxLenBelowMin:     "максимальная длина %d меньше минимальной длины генератора %d",
+	},
+	English: {
+		msgCharsetEmpty:               "no characters left after exclusions",
+		msgLeadingDigitOnlyDigits:     "cannot forbid a leading digit when the charset contains only digits",
+		msgMinClassesExceedsSets:      "%d distinct character sets required, but only %d are available",
+		msgLengthBelowMinClasses:      "password length (%d) is less than the required number of character sets (%d)",
+		msgSymbolAtEndNoSymbols:       "a symbol at the end requires the symbols set with at least one character left",
+		msgLengthExceedsCharset:       "password length (%d) exceeds the number of available unique characters (%d)",
+		msgLengthExceedsWeighted:      "password length (%d) exceeds the number of unique characters available with the given weights (%d)",
+		msgEntropyTooLowSets:          "configuration entropy (%.1f bits) is below the required %.1f bits: enable more character sets or allow repeats",
+		msgEntropyTooLowLength:        "configuration entropy (%.1f bits) is below the required %.1f bits: increase the length to at least %d characters or enable more character sets",
+		msgLengthNotPositive:          "password length must be a positive number",
+		msgMinLengthNotPositive:       "minimum password length must be a positive number",
+		msgMaxLengthBelowMin:          "maximum password length (%d) is less than the minimum (%d)",
+		msgNoCharsets:                 "at least one character set must be selected (digits, lower, upper, symbols, emoji or custom)",
+		msgNegativeMaxConsecutive:     "maximum number of consecutive identical characters cannot be negative",
+		msgNegativeMaxSequential:      "maximum sequence length cannot be negative",
+		msgNegativeMinClasses:         "minimum number of character sets cannot be negative",
+		msgNegativeMaxAttempts:        "attempt limit cannot be negative",
+		msgNegativeMinEntropy:         "minimum entropy cannot be negative",
+		msgUnknownWeightSet:           "unknown set %q in weights (allowed: digits, lower, upper, symbols, emoji, custom)",
+		msgNegativeWeight:             "weight of set %q cannot be negative",
+		msgUniqueAttemptsExhausted:    "failed to generate a unique password in %d attempts, the combination limit may have been reached",
+		msgNotEnoughForRequired:       "not enough characters to satisfy the requirements",
+		msgNotEnoughUnique:            "not enough unique characters",
+		msgCountNotPositive:           "password count must be a positive number",
+		msgCountExceedsMaxUnique:      "%d passwords requested, but only %s unique passwords are possible",
+		msgGenerateUniqueFailed:       "failed to generate %d unique passwords: %w",
+		msgRulesAttemptsExhausted:     "failed to generate a password satisfying the rules in %d attempts",
+		msgRandomFailed:               "random number generation failed after %d attempts: %w",
+		msgNoPositiveWeight:           "at least one of the selected sets must have a positive weight",
+		msgStoreSaveFailed:            "failed to save the password to the store: %w",
+		msgRandomMaxNotPositive:       "maximum must be a positive number",
+		msgCustomGroupNoName:          "a custom character group must have a name",
+		msgCustomGroupDuplicate:       "custom group name %q is already in use",
+		msgCustomGroupNegativeMin:     "minimum of group %q cannot be negative",
+		msgCustomGroupEmpty:           "group %q has no characters left, but a minimum of %d is required",
+		msgCustomGroupMinExceedsChars: "minimum of group %q (%d) exceeds its number of characters (%d) without repeats",
+		msgRequiredExceedsLength:      "password length (%d) is less than the number of required characters (%d)",
+		msgPatternNil:                 "regular expression is not set",
+		msgGenerateInterrupted:        "generation interrupted after %d of %d passwords: %w",
+		msgBestEffortShortfall:        "only %d of %d unique passwords could be generated: %w",
+		msgCombiningMark:              "set %q contains combining mark %U that does not form a single character after NFC normalization",
+		msgMasterEmpty:                "master password cannot be empty",
+		msgRequiredSetExcluded:        "all characters of set %q are excluded, but the password must contain a character from each set: remove the set or reduce the exclusions",
+		msgLayoutEmpty:                "layout cannot be empty",
+		msgLayoutUnknownClass:         "unknown character class %d at layout position %d",
+		msgNegativeMaxOccurrences:     "character occurrence limit cannot be negative",
+		msgLengthExceedsOccurrences:   "password length (%d) exceeds the %d characters available with at most %d occurrences of each character",
+		msgWorkersNotPositive:         "number of workers must be a positive number",
+		msgAlternateOneSet:            "alternating sets requires at least two character sets",
+		msgAlternateLength:            "length %d is too long for alternating sets without repeats, maximum is %d",
+		msgWordlistLineSpaces:         "wordlist %s, line %d: a word must not contain spaces",
+		msgWordlistTooSmall:           "wordlist %s has %d distinct words, at least %d are needed for strong passphrases",
+		msgWordlistEmpty:              "wordlist is empty",
+		msgWordsNotPositive:           "number of words must be a positive number",
+		msgNthOutOfRange:              "password number %d is out of range from 0 to %s (exclusive)",
+		msgBufferBelowAffixes:         "buffer of %d bytes is shorter than the prefix and suffix (%d bytes)",
+		msgBufferNonASCII:             "character %q takes more than one byte, only ASCII characters can be written to the buffer",
+		msgLengthBelowEntropyCount:    "length %d is too short: %.1f bits of entropy and %d unique passwords require at least %d characters",
+		msgPrefixMissing:              "password does not start with prefix %q",
+		msgSuffixMissing:              "password does not end with suffix %q",
+		msgLengthMismatch:             "password length is %d, %d required",
+		msgLengthOutOfRange:           "password length %d is out of range from %d to %d",
+		msgCharNotInCharset:           "character %q at index %d is not in the allowed charset",
+		msgCharRepeated:               "character %q at index %d is repeated, but repeats are not allowed",
+		msgCharTooFrequent:            "character %q at index %d occurs more than %d times",
+		msgTooFewClasses:              "password contains characters from %d sets, %d required",
+		msgMissingSet:                 "password contains no characters from set %q",
+		msgGroupBelowMin:              "password contains %d characters from set %q, at least %d required",
+		msgRulesViolated:              "password violates the rules: %w",
+		msgRuleConsecutive:            "more than %d identical characters in a row",
+		msgRuleSequential:             "sequence of adjacent characters is longer than %d",
+		msgRuleKeyboard:               "password contains a keyboard sequence",
+		msgRuleYear:                   "password contains the year %s",
+		msgRuleLeadingDigit:           "password starts with a digit",
+		msgRuleSymbolAtEnd:            "password does not end with a symbol",
+		msgRuleAdjacentClass:          "adjacent characters are from the same set",
+		msgRuleBlocked:                "password is in the blocklist",
+		msgBytesNotPositive:           "number of bytes must be a positive number",
+		msgUnknownEncoding:            "unknown encoding mode %q (hex and base64 are supported)",
+		msgRandomBytesFailed:          "failed to generate random bytes: %w",
+		msgSyllablesNotPositive:       "number of syllables must be a positive number",
+		msgPatternEmpty:               "pattern must not be empty",
+		msgPatternTrailingEscape:      "pattern ends with an unfinished escape",
+		msgPatternInvalidEscape:       "invalid escape %q at position %d",
+		msgCharsetStringEmpty:         "charset must not be empty",
+		msgProfanityAttemptsExhausted: "failed to generate a password without blocked words in %d attempts",
+		msgStatsExhaustionWarning:     "more than half of the possible combinations are used, increase the length or the charset",
+		msgStoreOpenFailed:            "failed to open the password store: %w",
+		msgStoreReadFailed:            "failed to read the password store: %w",
+		msgStoreWriteFailed:           "failed to write a password to the store: %w",
+		msgConfigReadFailed:           "failed to read the configuration file: %w",
+		msgConfigInvalid:              "invalid configuration file %s: %w",
+		msgWordlistOpenFailed:         "failed to open the wordlist: %w",
+		msgWordlistReadFailed:         "failed to read the wordlist: %w",
+		msgCompareConfigFailed:        "configuration %d: %w",
+		msgPatternAttemptsExhausted:   "failed to generate a password matching the pattern in %d attempts",
+		msgCrackUnderSecond:           "less than a second",
+		msgCrackYears:                 "year|years|years",
+		msgCrackDays:                  "day|days|days",
+		msgCrackHours:                 "hour|hours|hours",
+		msgCrackMinutes:               "minute|minutes|minutes",
+		msgCrackSeconds:               "second|seconds|seconds",
+		msgMatchingMaxLenBelowMin:     "maximum length %d is less than the generator's minimum length %d",
+	},
+}
+
+// msg возвращает шаблон сообщения на выбранном языке; при отсутствии
+// перевода используется русский вариант
+func msg(id messageID) string {
+	if text, ok := messages[Language][id]; ok {
+		return text
+	}
+	return messages[Russian][id]
+}
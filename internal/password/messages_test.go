@@ -0,0 +1,121 @@
+package password
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"testing"
+)
+
+// useLanguage переключает язык сообщений на время теста
+func useLanguage(t *testing.T, lang Lang) {
+	t.Helper()
+	previous := Language
+	Language = lang
+	t.Cleanup(func() { Language = previous })
+}
+
+func TestEnglishLengthExceedsCharset(t *testing.T) {
+	useLanguage(t, English)
+
+	_, err := NewGenerator(Config{Length: 11, UseDigits: true})
+	if err == nil {
+		t.Fatal("NewGenerator() expected error, got none")
+	}
+
+	want := "password length (11) exceeds the number of available unique characters (10)"
+	if err.Error() != want {
+		t.Errorf("error = %q, want %q", err, want)
+	}
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("errors.Is(%v, ErrInvalidConfig) = false", err)
+	}
+}
+
+func TestRussianLengthExceedsCharset(t *testing.T) {
+	useLanguage(t, Russian)
+
+	_, err := NewGenerator(Config{Length: 11, UseDigits: true})
+	want := "длина пароля (11) превышает количество доступных уникальных символов (10)"
+	if err == nil || err.Error() != want {
+		t.Errorf("error = %v, want %q", err, want)
+	}
+}
+
+func TestMessagesComplete(t *testing.T) {
+	verbs := regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+
+	for id, ru := range messages[Russian] {
+		en, ok := messages[English][id]
+		if !ok {
+			t.Errorf("message %d has no English translation", id)
+			continue
+		}
+		if !slices.Equal(verbs.FindAllString(ru, -1), verbs.FindAllString(en, -1)) {
+			t.Errorf("message %d: format verbs differ between %q and %q", id, ru, en)
+		}
+	}
+
+	if len(messages[English]) != len(messages[Russian]) {
+		t.Errorf("English has %d messages, Russian has %d", len(messages[English]), len(messages[Russian]))
+	}
+}
+
+func TestEnglishHelperMessages(t *testing.T) {
+	useLanguage(t, English)
+
+	gen, err := NewGenerator(Config{Length: 8, UseLower: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+		want string
+		kind error
+	}{
+		{
+			name: "Estimate",
+			call: func() error { _, err := gen.Estimate(0); return err },
+			want: "password count must be a positive number",
+			kind: ErrInvalidCount,
+		},
+		{
+			name: "NewGeneratorFromString",
+			call: func() error { _, err := NewGeneratorFromString(8, ""); return err },
+			want: "charset must not be empty",
+			kind: ErrInvalidConfig,
+		},
+		{
+			name: "GeneratePronounceable",
+			call: func() error { _, err := GeneratePronounceable(0); return err },
+			want: "password length must be a positive number",
+			kind: ErrInvalidConfig,
+		},
+		{
+			name: "GenerateEncoded",
+			call: func() error { _, err := GenerateEncoded(16, "base32"); return err },
+			want: `unknown encoding mode "base32" (hex and base64 are supported)`,
+			kind: ErrInvalidConfig,
+		},
+		{
+			name: "GenerateFromPattern",
+			call: func() error { _, err := GenerateFromPattern(`A\x`); return err },
+			want: `invalid escape "x" at position 1`,
+			kind: ErrInvalidConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if err == nil || err.Error() != tt.want {
+				t.Fatalf("error = %v, want %q", err, tt.want)
+			}
+			if !errors.Is(err, tt.kind) {
+				t.Errorf("errors.Is(%v, %v) = false", err, tt.kind)
+			}
+		})
+	}
+}
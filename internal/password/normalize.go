@@ -0,0 +1,26 @@
+package password
+
+import (
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// normalizeChars приводит набор символов к форме NFC: "e" с комбинируемым
+// акутом (U+0065 U+0301) становится одной руной "é" (U+00E9). Без этого
+// такие символы разбивались бы на отдельные руны набора.
+func normalizeChars(chars string) string {
+	return norm.NFC.String(chars)
+}
+
+// checkSingleCodePoints проверяет, что после нормализации набор name не
+// содержит комбинируемых знаков: у сочетаний вроде "q" с акутом нет
+// составной формы, и знак попал бы в пароль отдельным символом
+func checkSingleCodePoints(name, chars string) error {
+	for _, r := range normalizeChars(chars) {
+		if unicode.Is(unicode.M, r) {
+			return errorf(ErrInvalidConfig, msg(msgCombiningMark), name, r)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package password
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCustomCharsNormalized(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		want   string
+	}{
+		{
+			name:   "разложенные буквы",
+			config: Config{Length: 2, CustomChars: "e\u0301a\u0301"},
+			want:   "\u00e1\u00e9",
+		},
+		{
+			name:   "составные буквы",
+			config: Config{Length: 2, CustomChars: "\u00e9\u00e1"},
+			want:   "\u00e1\u00e9",
+		},
+		{
+			name:   "разложенная и составная форма - один символ",
+			config: Config{Length: 2, CustomChars: "e\u0301\u00e9x"},
+			want:   "x\u00e9",
+		},
+		{
+			name:   "исключение в разложенной форме",
+			config: Config{Length: 1, CustomChars: "\u00e9x", ExcludeChars: "e\u0301"},
+			want:   "x",
+		},
+		{
+			name:   "дополнительный набор",
+			config: Config{Length: 1, CustomGroups: []CustomGroup{{Name: "accents", Chars: "o\u0308"}}},
+			want:   "\u00f6",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen, err := NewGenerator(tt.config)
+			if err != nil {
+				t.Fatalf("NewGenerator() failed: %v", err)
+			}
+			if got := gen.Charset(); got != tt.want {
+				t.Errorf("Charset() = %+q, want %+q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCustomCharsCombiningMarkRejected(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		// У "q" с акутом нет составной формы
+		{name: "custom", config: Config{Length: 2, CustomChars: "abq\u0301"}},
+		{name: "одиночный знак", config: Config{Length: 1, CustomChars: "\u0301"}},
+		{name: "дополнительный набор", config: Config{Length: 1, CustomGroups: []CustomGroup{{Name: "marks", Chars: "x\u0323\u0307"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewGenerator(tt.config); !errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("NewGenerator() error = %v, want ErrInvalidConfig", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package password
+
+import (
+	"math/big"
+	"slices"
+)
+
+// NthPassword возвращает пароль с номером n (от 0) в фиксированном
+// порядке всех паролей конфигурации: сначала по длине, затем
+// лексикографически по порядку символов в Charset. Без повторов номер
+// раскладывается по факториальной системе (код Лемера), с повторами - по
+// основанию len(Charset). Отображение взаимно однозначно на [0, MaxUnique),
+// для n вне диапазона возвращается ErrInvalidCount.
+//
+// Как и MaxUnique, перечисление не учитывает обязательные наборы,
+// дополнительные правила и предел вхождений, поэтому пароль может не
+// пройти Validate. Метод предназначен для тестов и перебора небольших
+// пространств; выданные пароли не запоминаются.
+func (g *Generator) NthPassword(n int) (string, error) {
+	maxUnique := g.MaxUnique()
+	rank := big.NewInt(int64(n))
+	if n < 0 || rank.Cmp(maxUnique) >= 0 {
+		return "", errorf(ErrInvalidCount, msg(msgNthOutOfRange), n, maxUnique)
+	}
+
+	minLength, maxLength := g.length, g.length
+	if g.length == 0 {
+		minLength, maxLength = g.minLength, g.maxLength
+	}
+
+	// Пропускаем пароли более коротких длин
+	length := minLength
+	for ; length < maxLength; length++ {
+		count := countPasswords(len(g.charset), length, g.allowRepeats)
+		if rank.Cmp(count) < 0 {
+			break
+		}
+		rank.Sub(rank, count)
+	}
+
+	// Наборы в g.charset идут в порядке включения, а Charset отсортирован
+	available := slices.Clone(g.charset)
+	slices.Sort(available)
+	result := make([]rune, length)
+	idx := new(big.Int)
+	for i := range result {
+		// Число продолжений после выбора символа в позиции i
+		block := countPasswords(len(available)-1, length-i-1, false)
+		if g.allowRepeats {
+			block = countPasswords(len(available), length-i-1, true)
+		}
+		idx.DivMod(rank, block, rank)
+
+		pos := int(idx.Int64())
+		result[i] = available[pos]
+		if !g.allowRepeats {
+			available = append(available[:pos], available[pos+1:]...)
+		}
+	}
+
+	return g.withAffixes(string(result)), nil
+}
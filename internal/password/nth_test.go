@@ -0,0 +1,136 @@
+package password
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestNthPasswordEnumeratesAll(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 3, UseDigits: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	total := int(gen.MaxUnique().Int64())
+	if total != 720 {
+		t.Fatalf("MaxUnique() = %d, want 720", total)
+	}
+
+	seen := make(map[string]int, total)
+	prev := ""
+	for n := 0; n < total; n++ {
+		pwd, err := gen.NthPassword(n)
+		if err != nil {
+			t.Fatalf("NthPassword(%d) failed: %v", n, err)
+		}
+		if other, exists := seen[pwd]; exists {
+			t.Fatalf("NthPassword(%d) = %q, same as NthPassword(%d)", n, pwd, other)
+		}
+		seen[pwd] = n
+
+		// Порядок лексикографический, а пароли проходят политику генератора
+		if pwd <= prev {
+			t.Errorf("NthPassword(%d) = %q is not after %q", n, pwd, prev)
+		}
+		prev = pwd
+		if err := gen.Validate(pwd); err != nil {
+			t.Errorf("Validate(%q) = %v", pwd, err)
+		}
+	}
+
+	// 720 разных паролей из 720 возможных - отображение взаимно однозначно
+	if len(seen) != total {
+		t.Errorf("got %d distinct passwords, want %d", len(seen), total)
+	}
+	for _, want := range []string{"012", "987", "540"} {
+		if _, ok := seen[want]; !ok {
+			t.Errorf("permutation %q was not enumerated", want)
+		}
+	}
+
+	// Повторный вызов даёт тот же пароль
+	for pwd, n := range seen {
+		if again, _ := gen.NthPassword(n); again != pwd {
+			t.Errorf("NthPassword(%d) = %q, then %q", n, pwd, again)
+		}
+	}
+}
+
+func TestNthPassword(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		n      int
+		want   string
+	}{
+		{"первый без повторов", Config{Length: 3, UseDigits: true}, 0, "012"},
+		{"последний без повторов", Config{Length: 3, UseDigits: true}, 719, "987"},
+		{"с повторами - запись числа", Config{Length: 3, UseDigits: true, AllowRepeats: true}, 42, "042"},
+		{"диапазон длин - короткие первыми", Config{MinLength: 1, MaxLength: 2, UseDigits: true}, 9, "9"},
+		{"диапазон длин - затем длинные", Config{MinLength: 1, MaxLength: 2, UseDigits: true}, 10, "01"},
+		{"с префиксом", Config{Length: 2, UseDigits: true, Prefix: "id-"}, 1, "id-02"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen, err := NewGenerator(tt.config)
+			if err != nil {
+				t.Fatalf("NewGenerator() failed: %v", err)
+			}
+			got, err := gen.NthPassword(tt.n)
+			if err != nil {
+				t.Fatalf("NthPassword(%d) failed: %v", tt.n, err)
+			}
+			if got != tt.want {
+				t.Errorf("NthPassword(%d) = %q, want %q", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNthPasswordOutOfRange(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 3, UseDigits: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	for _, n := range []int{-1, 720, 1000} {
+		t.Run(strconv.Itoa(n), func(t *testing.T) {
+			if _, err := gen.NthPassword(n); !errors.Is(err, ErrInvalidCount) {
+				t.Errorf("NthPassword(%d) error = %v, want ErrInvalidCount", n, err)
+			}
+		})
+	}
+}
+
+// Порядок перечисления совпадает с порядком Charset, а не с порядком
+// включения наборов
+func TestNthPasswordFollowsCharsetOrder(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 2, UseLower: true, UseUpper: true, AllowRepeats: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	// Charset начинается с "ABC...", хотя набор lower включён раньше upper
+	last := int(gen.MaxUnique().Int64()) - 1
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{n: 0, want: "AA"},
+		{n: 1, want: "AB"},
+		{n: 26, want: "Aa"},
+		{n: last, want: "zz"},
+	}
+
+	for _, tt := range tests {
+		got, err := gen.NthPassword(tt.n)
+		if err != nil {
+			t.Fatalf("NthPassword(%d) failed: %v", tt.n, err)
+		}
+		if got != tt.want {
+			t.Errorf("NthPassword(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
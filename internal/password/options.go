@@ -0,0 +1,74 @@
+package password
+
+// Option изменяет конфигурацию генератора при создании через NewGeneratorWithOptions
+type Option func(*Config)
+
+// WithDigits включает цифры 0-9
+func WithDigits() Option {
+	return func(c *Config) {
+		c.UseDigits = true
+	}
+}
+
+// WithLower включает маленькие буквы a-z
+func WithLower() Option {
+	return func(c *Config) {
+		c.UseLower = true
+	}
+}
+
+// WithUpper включает большие буквы A-Z
+func WithUpper() Option {
+	return func(c *Config) {
+		c.UseUpper = true
+	}
+}
+
+// WithSymbols включает специальные символы
+func WithSymbols() Option {
+	return func(c *Config) {
+		c.UseSymbols = true
+	}
+}
+
+// WithEmoji включает набор эмодзи
+func WithEmoji() Option {
+	return func(c *Config) {
+		c.UseEmoji = true
+	}
+}
+
+// WithExclude исключает заданные символы из всех наборов
+func WithExclude(chars string) Option {
+	return func(c *Config) {
+		c.ExcludeChars += chars
+	}
+}
+
+// WithAllowRepeats разрешает повторение символов внутри пароля
+func WithAllowRepeats() Option {
+	return func(c *Config) {
+		c.AllowRepeats = true
+	}
+}
+
+// NewGeneratorWithOptions создаёт генератор из длины и набора опций.
+// Конфигурация проходит ту же валидацию, что и в NewGenerator.
+func NewGeneratorWithOptions(length int, opts ...Option) (*Generator, error) {
+	config := Config{Length: length}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return NewGenerator(config)
+}
+
+// NewGeneratorFromString создаёт генератор из готового алфавита: вся строка
+// считается одним набором символов, повторяющиеся руны удаляются.
+func NewGeneratorFromString(length int, charset string) (*Generator, error) {
+	if charset == "" {
+		return nil, errorf(ErrInvalidConfig, msg(msgCharsetStringEmpty))
+	}
+
+	return NewGenerator(Config{Length: length, CustomChars: charset})
+}
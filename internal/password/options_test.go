@@ -0,0 +1,135 @@
+package password
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestNewGeneratorWithOptions(t *testing.T) {
+	tests := []struct {
+		name   string
+		length int
+		opts   []Option
+		config Config
+	}{
+		{
+			name:   "только digits",
+			length: 6,
+			opts:   []Option{WithDigits()},
+			config: Config{Length: 6, UseDigits: true},
+		},
+		{
+			name:   "все наборы с символами",
+			length: 16,
+			opts:   []Option{WithDigits(), WithLower(), WithUpper(), WithSymbols()},
+			config: Config{Length: 16, UseDigits: true, UseLower: true, UseUpper: true, UseSymbols: true},
+		},
+		{
+			name:   "исключение символов",
+			length: 8,
+			opts:   []Option{WithLower(), WithDigits(), WithExclude("0o"), WithExclude("1l")},
+			config: Config{Length: 8, UseLower: true, UseDigits: true, ExcludeChars: "0o1l"},
+		},
+		{
+			name:   "повторы разрешены",
+			length: 20,
+			opts:   []Option{WithDigits(), WithAllowRepeats()},
+			config: Config{Length: 20, UseDigits: true, AllowRepeats: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fromOpts, err := NewGeneratorWithOptions(tt.length, tt.opts...)
+			if err != nil {
+				t.Fatalf("NewGeneratorWithOptions() failed: %v", err)
+			}
+
+			fromConfig, err := NewGenerator(tt.config)
+			if err != nil {
+				t.Fatalf("NewGenerator() failed: %v", err)
+			}
+
+			if !reflect.DeepEqual(fromOpts, fromConfig) {
+				t.Errorf("NewGeneratorWithOptions() = %+v, want %+v", fromOpts, fromConfig)
+			}
+		})
+	}
+}
+
+func TestNewGeneratorWithOptionsValidation(t *testing.T) {
+	if _, err := NewGeneratorWithOptions(10); err == nil {
+		t.Error("Expected error when no charset options given, got none")
+	}
+
+	if _, err := NewGeneratorWithOptions(11, WithDigits()); err == nil {
+		t.Error("Expected error when length exceeds charset without repeats, got none")
+	}
+
+	if _, err := NewGeneratorWithOptions(4, WithDigits(), WithExclude(digits)); err == nil {
+		t.Error("Expected error when all characters are excluded, got none")
+	}
+}
+
+func TestGenerateWithExcludeAndRepeats(t *testing.T) {
+	gen, err := NewGeneratorWithOptions(30, WithDigits(), WithExclude("0123"), WithAllowRepeats())
+	if err != nil {
+		t.Fatalf("NewGeneratorWithOptions() failed: %v", err)
+	}
+
+	passwords, err := gen.GenerateUnique(20)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+
+	for _, password := range passwords {
+		if len(password) != 30 {
+			t.Errorf("Password length = %d, want 30", len(password))
+		}
+		if strings.ContainsAny(password, "0123") {
+			t.Errorf("Password %q contains excluded character", password)
+		}
+	}
+}
+
+func TestNewGeneratorFromString(t *testing.T) {
+	gen, err := NewGeneratorFromString(5, "aabbccddeeжж")
+	if err != nil {
+		t.Fatalf("NewGeneratorFromString() failed: %v", err)
+	}
+
+	if got := string(gen.charset); got != "abcdeж" {
+		t.Errorf("charset = %q, want %q", got, "abcdeж")
+	}
+	if len(gen.charsets) != 1 {
+		t.Errorf("charsets count = %d, want 1", len(gen.charsets))
+	}
+
+	passwords, err := gen.GenerateUnique(50)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+	for _, password := range passwords {
+		if n := utf8.RuneCountInString(password); n != 5 {
+			t.Errorf("Password %q length = %d, want 5", password, n)
+		}
+		for _, r := range password {
+			if !strings.ContainsRune("abcdeж", r) {
+				t.Errorf("Password %q contains unexpected character %q", password, r)
+			}
+		}
+	}
+}
+
+func TestNewGeneratorFromStringValidation(t *testing.T) {
+	if _, err := NewGeneratorFromString(4, ""); err == nil {
+		t.Error("Expected error for empty charset, got none")
+	}
+
+	// После дедупликации остаётся 3 символа - длина 4 без повторов невозможна
+	if _, err := NewGeneratorFromString(4, "aabbcc"); err == nil {
+		t.Error("Expected error when length exceeds deduplicated charset, got none")
+	}
+}
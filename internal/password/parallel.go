@@ -0,0 +1,63 @@
+package password
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// GenerateManyParallel генерирует count независимых паролей, как GenerateMany,
+// распределяя работу между workers горутинами. crypto/rand безопасен для
+// одновременного использования, а общей карты used у независимых паролей
+// нет, поэтому горутины не синхронизируются между собой. Порядок и число
+// паролей в результате не зависят от workers. После первой ошибки
+// остальные горутины останавливаются, и ошибка возвращается.
+//
+// Генераторы с детерминированным источником (NewSeededGenerator,
+// NewGeneratorWithReader) генерируют последовательно: их источник не
+// рассчитан на одновременное чтение. Config.Accept должен быть безопасен
+// для вызова из нескольких горутин.
+func (g *Generator) GenerateManyParallel(count, workers int) ([]string, error) {
+	if count <= 0 {
+		return nil, errorf(ErrInvalidCount, msg(msgCountNotPositive))
+	}
+	if workers <= 0 {
+		return nil, errorf(ErrInvalidConfig, msg(msgWorkersNotPositive))
+	}
+	if workers == 1 || g.rng != nil || g.random != nil {
+		return g.GenerateMany(count)
+	}
+	workers = min(workers, count)
+
+	result := make([]string, count)
+	errs := make([]error, workers)
+	var failed atomic.Bool
+	var wg sync.WaitGroup
+
+	// Каждая горутина заполняет свой непрерывный участок result
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w * count / workers; i < (w+1)*count/workers; i++ {
+				if failed.Load() {
+					return
+				}
+				password, err := g.generateValid()
+				if err != nil {
+					errs[w] = err
+					failed.Store(true)
+					return
+				}
+				result[i] = password
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
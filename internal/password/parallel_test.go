@@ -0,0 +1,113 @@
+package password
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"unicode/utf8"
+)
+
+// Тест рассчитан на запуск с детектором гонок: go test -race ./...
+func TestGenerateManyParallel(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 12, UseDigits: true, UseLower: true, UseUpper: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	for _, tt := range []struct{ count, workers int }{
+		{count: 1, workers: 4},
+		{count: 10, workers: 3},
+		{count: 1000, workers: 8},
+		{count: 7, workers: 100},
+	} {
+		t.Run(fmt.Sprintf("%d/%d", tt.count, tt.workers), func(t *testing.T) {
+			passwords, err := gen.GenerateManyParallel(tt.count, tt.workers)
+			if err != nil {
+				t.Fatalf("GenerateManyParallel() failed: %v", err)
+			}
+			if len(passwords) != tt.count {
+				t.Fatalf("got %d passwords, want %d", len(passwords), tt.count)
+			}
+			for _, pwd := range passwords {
+				if utf8.RuneCountInString(pwd) != 12 {
+					t.Errorf("Password %q has length %d, want 12", pwd, utf8.RuneCountInString(pwd))
+				}
+				if err := gen.Validate(pwd); err != nil {
+					t.Errorf("Validate(%q) failed: %v", pwd, err)
+				}
+			}
+		})
+	}
+
+	// Независимые пароли не попадают в карту used
+	if len(gen.used) != 0 {
+		t.Errorf("used has %d entries, want 0", len(gen.used))
+	}
+}
+
+func TestGenerateManyParallelErrors(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 8, UseLower: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+	if _, err := gen.GenerateManyParallel(0, 4); !errors.Is(err, ErrInvalidCount) {
+		t.Errorf("GenerateManyParallel(0, 4) error = %v, want ErrInvalidCount", err)
+	}
+	if _, err := gen.GenerateManyParallel(10, 0); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("GenerateManyParallel(10, 0) error = %v, want ErrInvalidConfig", err)
+	}
+
+	// Ошибка одной горутины возвращается вызывающему
+	never, err := NewGenerator(Config{Length: 4, UseDigits: true, MaxAttempts: 10, Accept: func(string) bool { return false }})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+	if _, err := never.GenerateManyParallel(100, 4); !errors.Is(err, ErrCharsetExhausted) {
+		t.Errorf("GenerateManyParallel() error = %v, want ErrCharsetExhausted", err)
+	}
+}
+
+func TestGenerateManyParallelSeeded(t *testing.T) {
+	// Детерминированный источник читается последовательно, результат
+	// совпадает с GenerateMany
+	config := Config{Length: 10, UseLower: true, UseDigits: true}
+	parallel, err := NewSeededGenerator(config, 42)
+	if err != nil {
+		t.Fatalf("NewSeededGenerator() failed: %v", err)
+	}
+	sequential, err := NewSeededGenerator(config, 42)
+	if err != nil {
+		t.Fatalf("NewSeededGenerator() failed: %v", err)
+	}
+
+	got, err := parallel.GenerateManyParallel(20, 4)
+	if err != nil {
+		t.Fatalf("GenerateManyParallel() failed: %v", err)
+	}
+	want, err := sequential.GenerateMany(20)
+	if err != nil {
+		t.Fatalf("GenerateMany() failed: %v", err)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("GenerateManyParallel()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func BenchmarkGenerateManyParallel(b *testing.B) {
+	gen, err := NewGenerator(Config{Length: 16, UseDigits: true, UseLower: true, UseUpper: true, UseSymbols: true})
+	if err != nil {
+		b.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := gen.GenerateManyParallel(1000, workers); err != nil {
+					b.Fatalf("GenerateManyParallel() failed: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package password
+
+// patternClasses связывает символы-заполнители шаблона с наборами символов
+var patternClasses = map[rune]string{
+	'A': upper,
+	'a': lower,
+	'9': digits,
+	'#': symbols,
+}
+
+// GenerateFromPattern генерирует пароль по шаблону, например "AAA-999-aaa".
+// Заполнители: A - большая буква, a - маленькая буква, 9 - цифра, # - спецсимвол.
+// Остальные символы копируются как есть. Обратная косая черта экранирует
+// заполнитель или саму себя: "\A" даёт букву A, "\\" - символ "\".
+func GenerateFromPattern(pattern string) (string, error) {
+	if pattern == "" {
+		return "", errorf(ErrInvalidConfig, msg(msgPatternEmpty))
+	}
+
+	runes := []rune(pattern)
+	result := make([]rune, 0, len(runes))
+
+	for i := 0; i < len(runes); i++ {
+		char := runes[i]
+
+		if char == '\\' {
+			if i+1 >= len(runes) {
+				return "", errorf(ErrInvalidConfig, msg(msgPatternTrailingEscape))
+			}
+			next := runes[i+1]
+			if _, ok := patternClasses[next]; !ok && next != '\\' {
+				return "", errorf(ErrInvalidConfig, msg(msgPatternInvalidEscape), string(next), i)
+			}
+			result = append(result, next)
+			i++
+			continue
+		}
+
+		class, ok := patternClasses[char]
+		if !ok {
+			result = append(result, char)
+			continue
+		}
+
+		classRunes := []rune(class)
+		idx, err := secureRandomInt(len(classRunes))
+		if err != nil {
+			return "", err
+		}
+		result = append(result, classRunes[idx])
+	}
+
+	return string(result), nil
+}
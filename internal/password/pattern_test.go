@@ -0,0 +1,80 @@
+package password
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateFromPattern(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+	}{
+		{name: "смешанный шаблон", pattern: "AAA-999-aaa"},
+		{name: "со спецсимволом", pattern: "Aa9#"},
+		{name: "только литералы", pattern: "key_"},
+		{name: "не-ASCII литералы", pattern: "ключ-999"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			password, err := GenerateFromPattern(tt.pattern)
+			if err != nil {
+				t.Fatalf("GenerateFromPattern(%q) failed: %v", tt.pattern, err)
+			}
+
+			got := []rune(password)
+			want := []rune(tt.pattern)
+			if len(got) != len(want) {
+				t.Fatalf("GenerateFromPattern(%q) = %q, length mismatch", tt.pattern, password)
+			}
+
+			for i, placeholder := range want {
+				if set, ok := patternClasses[placeholder]; ok {
+					if !strings.ContainsRune(set, got[i]) {
+						t.Errorf("position %d = %c, not in class %c", i, got[i], placeholder)
+					}
+				} else if got[i] != placeholder {
+					t.Errorf("position %d = %c, want literal %c", i, got[i], placeholder)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateFromPatternEscapes(t *testing.T) {
+	password, err := GenerateFromPattern(`\A\9\a\#\\-x`)
+	if err != nil {
+		t.Fatalf("GenerateFromPattern() failed: %v", err)
+	}
+	if want := `A9a#\-x`; password != want {
+		t.Errorf("GenerateFromPattern() = %q, want %q", password, want)
+	}
+
+	password, err = GenerateFromPattern(`\A9`)
+	if err != nil {
+		t.Fatalf("GenerateFromPattern() failed: %v", err)
+	}
+	if len(password) != 2 || password[0] != 'A' || !strings.ContainsRune(digits, rune(password[1])) {
+		t.Errorf("GenerateFromPattern(%q) = %q, want literal A followed by digit", `\A9`, password)
+	}
+}
+
+func TestGenerateFromPatternErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+	}{
+		{name: "пустой шаблон", pattern: ""},
+		{name: "незавершённое экранирование", pattern: `AA\`},
+		{name: "недопустимое экранирование", pattern: `A\x9`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := GenerateFromPattern(tt.pattern); err == nil {
+				t.Errorf("GenerateFromPattern(%q) expected error, got none", tt.pattern)
+			}
+		})
+	}
+}
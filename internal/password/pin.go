@@ -0,0 +1,16 @@
+package password
+
+// GeneratePIN генерирует числовой PIN-код заданной длины.
+// Повторы цифр разрешены, так как PIN вида 1122 вполне допустим.
+func GeneratePIN(length int) (string, error) {
+	gen, err := NewGenerator(Config{
+		Length:       length,
+		UseDigits:    true,
+		AllowRepeats: true,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return gen.Generate()
+}
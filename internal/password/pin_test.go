@@ -0,0 +1,43 @@
+package password
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGeneratePIN(t *testing.T) {
+	for _, length := range []int{4, 6, 12} {
+		pin, err := GeneratePIN(length)
+		if err != nil {
+			t.Fatalf("GeneratePIN(%d) failed: %v", length, err)
+		}
+
+		if len(pin) != length {
+			t.Errorf("GeneratePIN(%d) length = %d", length, len(pin))
+		}
+
+		for _, char := range pin {
+			if !strings.ContainsRune(digits, char) {
+				t.Errorf("PIN %q contains non-digit character %c", pin, char)
+			}
+		}
+	}
+}
+
+func TestGeneratePINAllowsRepeats(t *testing.T) {
+	// PIN длиннее 10 цифр невозможен без повторов
+	pin, err := GeneratePIN(20)
+	if err != nil {
+		t.Fatalf("GeneratePIN(20) failed: %v", err)
+	}
+
+	if len(pin) != 20 {
+		t.Errorf("PIN length = %d, want 20", len(pin))
+	}
+}
+
+func TestGeneratePINInvalidLength(t *testing.T) {
+	if _, err := GeneratePIN(0); err == nil {
+		t.Error("Expected error for zero length PIN, got none")
+	}
+}
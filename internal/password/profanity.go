@@ -0,0 +1,58 @@
+package password
+
+import "strings"
+
+// defaultProfanity - небольшой список английских нецензурных и оскорбительных
+// корней, которые могут случайно сложиться из слогов
+var defaultProfanity = []string{
+	"anal", "anus", "arse", "ass", "bitch", "butt", "cock", "cum", "cunt",
+	"damn", "dick", "dildo", "fag", "fuck", "fuk", "gay", "homo", "jiz",
+	"kike", "nazi", "nig", "penis", "piss", "poop", "porn", "pussy", "rape",
+	"sex", "shit", "slut", "tit", "twat", "vagina", "whore",
+}
+
+// ProfanityFilter отклоняет тексты, содержащие запрещённые подстроки (без учёта регистра)
+type ProfanityFilter struct {
+	words []string
+}
+
+// DefaultProfanityFilter использует встроенный английский список
+var DefaultProfanityFilter = NewProfanityFilter(defaultProfanity)
+
+// NewProfanityFilter создаёт фильтр из списка запрещённых подстрок; пустые строки пропускаются
+func NewProfanityFilter(words []string) *ProfanityFilter {
+	filter := &ProfanityFilter{}
+	for _, word := range words {
+		if word != "" {
+			filter.words = append(filter.words, strings.ToLower(word))
+		}
+	}
+	return filter
+}
+
+// Contains проверяет, содержит ли текст хотя бы одну запрещённую подстроку
+func (f *ProfanityFilter) Contains(text string) bool {
+	lowered := strings.ToLower(text)
+	for _, word := range f.words {
+		if strings.Contains(lowered, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// generateFiltered вызывает generate, пока результат не пройдёт фильтр.
+// При filter == nil первый результат возвращается без проверки.
+func generateFiltered(filter *ProfanityFilter, generate func() (string, error)) (string, error) {
+	for attempt := 0; attempt < defaultMaxAttempts; attempt++ {
+		password, err := generate()
+		if err != nil {
+			return "", err
+		}
+		if filter == nil || !filter.Contains(password) {
+			return password, nil
+		}
+	}
+
+	return "", errorf(ErrCharsetExhausted, msg(msgProfanityAttemptsExhausted), defaultMaxAttempts)
+}
@@ -0,0 +1,91 @@
+package password
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProfanityFilterContains(t *testing.T) {
+	filter := NewProfanityFilter([]string{"Bad", "", "zog"})
+
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{text: "tobadeki", want: true},
+		{text: "TOBADEKI", want: true},
+		{text: "mizoga", want: true},
+		{text: "tobakemi", want: false},
+		{text: "", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := filter.Contains(tt.text); got != tt.want {
+			t.Errorf("Contains(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestGeneratePronounceableFiltered(t *testing.T) {
+	// Короткие слоги встречаются часто, поэтому фильтр реально срабатывает
+	banned := []string{"ba", "ko", "zu", "MI"}
+	filter := NewProfanityFilter(banned)
+
+	for i := 0; i < 300; i++ {
+		password, err := GeneratePronounceableFiltered(10, filter)
+		if err != nil {
+			t.Fatalf("GeneratePronounceableFiltered() failed: %v", err)
+		}
+		if len(password) != 10 {
+			t.Errorf("Password %q length = %d, want 10", password, len(password))
+		}
+		for _, word := range banned {
+			if strings.Contains(password, strings.ToLower(word)) {
+				t.Errorf("Password %q contains banned %q", password, word)
+			}
+		}
+	}
+}
+
+func TestGenerateSyllabicFiltered(t *testing.T) {
+	banned := []string{"bo", "ka", "9", "ze"}
+	filter := NewProfanityFilter(banned)
+
+	for i := 0; i < 300; i++ {
+		password, err := GenerateSyllabicFiltered(3, filter)
+		if err != nil {
+			t.Fatalf("GenerateSyllabicFiltered() failed: %v", err)
+		}
+		for _, word := range banned {
+			if strings.Contains(password, word) {
+				t.Errorf("Password %q contains banned %q", password, word)
+			}
+		}
+	}
+}
+
+func TestGenerateFilteredImpossible(t *testing.T) {
+	// Каждый пароль содержит гласную, поэтому фильтр по всем гласным невыполним
+	filter := NewProfanityFilter(strings.Split(vowels, ""))
+	if _, err := GeneratePronounceableFiltered(4, filter); err == nil {
+		t.Error("Expected error when filter rejects every password, got none")
+	}
+}
+
+func TestDefaultProfanityFilter(t *testing.T) {
+	for _, word := range defaultProfanity {
+		if !DefaultProfanityFilter.Contains("xx" + strings.ToUpper(word) + "xx") {
+			t.Errorf("DefaultProfanityFilter does not reject %q", word)
+		}
+	}
+
+	for i := 0; i < 100; i++ {
+		password, err := GenerateSyllabicFiltered(4, DefaultProfanityFilter)
+		if err != nil {
+			t.Fatalf("GenerateSyllabicFiltered() failed: %v", err)
+		}
+		if DefaultProfanityFilter.Contains(password) {
+			t.Errorf("Password %q passed the default filter but contains profanity", password)
+		}
+	}
+}
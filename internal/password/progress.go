@@ -0,0 +1,30 @@
+package password
+
+// progressSteps - сколько раз за пачку вызывается Config.Progress, не считая конца
+const progressSteps = 100
+
+// GenerateUniqueWithProgress генерирует count уникальных паролей, как
+// GenerateUnique, и вызывает progress примерно после каждого процента
+// паролей, а также после последнего. Значения done возрастают и
+// заканчиваются на total. Заменяет Config.Progress на время вызова.
+func (g *Generator) GenerateUniqueWithProgress(count int, progress func(done, total int)) ([]string, error) {
+	// Копия делит с g множество used, хранилище и источник случайности
+	override := *g
+	override.progress = progress
+	passwords, err := override.GenerateUnique(count)
+	g.attempts = override.attempts
+	return passwords, err
+}
+
+// reportProgress вызывает обработчик прогресса каждые total/progressSteps
+// паролей и после последнего
+func (g *Generator) reportProgress(done, total int) {
+	if g.progress == nil {
+		return
+	}
+
+	step := max(total/progressSteps, 1)
+	if done%step == 0 || done == total {
+		g.progress(done, total)
+	}
+}
@@ -0,0 +1,76 @@
+package password
+
+import "testing"
+
+func TestGenerateUniqueWithProgress(t *testing.T) {
+	tests := []struct {
+		name      string
+		count     int
+		wantCalls int
+	}{
+		{name: "меньше шага", count: 50, wantCalls: 50},
+		{name: "ровно сто шагов", count: 1000, wantCalls: 100},
+		{name: "неровное количество", count: 1055, wantCalls: 106},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen, err := NewGenerator(Config{Length: 10, UseDigits: true, UseLower: true})
+			if err != nil {
+				t.Fatalf("NewGenerator() failed: %v", err)
+			}
+
+			var calls, last int
+			progress := func(done, total int) {
+				calls++
+				if done <= last {
+					t.Errorf("progress done = %d after %d, want increasing values", done, last)
+				}
+				if total != tt.count {
+					t.Errorf("progress total = %d, want %d", total, tt.count)
+				}
+				last = done
+			}
+
+			passwords, err := gen.GenerateUniqueWithProgress(tt.count, progress)
+			if err != nil {
+				t.Fatalf("GenerateUniqueWithProgress() failed: %v", err)
+			}
+			if len(passwords) != tt.count {
+				t.Errorf("got %d passwords, want %d", len(passwords), tt.count)
+			}
+			if last != tt.count {
+				t.Errorf("last progress done = %d, want %d", last, tt.count)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("progress called %d times, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestConfigProgress(t *testing.T) {
+	var last int
+	gen, err := NewGenerator(Config{Length: 8, UseLower: true, Progress: func(done, total int) { last = done }})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	if _, err := gen.GenerateUniqueVaried(30); err != nil {
+		t.Fatalf("GenerateUniqueVaried() failed: %v", err)
+	}
+	if last != 30 {
+		t.Errorf("last progress done = %d, want 30", last)
+	}
+
+	// Прогресс, переданный в GenerateUniqueWithProgress, не заменяет настройку генератора
+	if _, err := gen.GenerateUniqueWithProgress(5, nil); err != nil {
+		t.Fatalf("GenerateUniqueWithProgress() failed: %v", err)
+	}
+	if _, err := gen.GenerateUnique(10); err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+	if last != 10 {
+		t.Errorf("last progress done = %d, want 10", last)
+	}
+}
@@ -0,0 +1,138 @@
+package password
+
+const (
+	consonants = "bcdfghjklmnprstvz"
+	vowels     = "aeiou"
+)
+
+// GeneratePronounceable генерирует легко произносимый пароль из чередующихся
+// согласных и гласных, например "tobakemi". Начало (с согласной или гласной)
+// выбирается случайно, каждый символ выбирается через secureRandomInt.
+func GeneratePronounceable(length int) (string, error) {
+	return GeneratePronounceableFiltered(length, nil)
+}
+
+// PronounceableOptions - дополнительные параметры произносимого пароля
+type PronounceableOptions struct {
+	// RequireDigit вставляет одну случайную цифру в случайную позицию
+	// пароля - для систем, требующих хотя бы одну цифру. Длина пароля не
+	// меняется: букв становится на одну меньше.
+	RequireDigit bool
+	// Filter отбрасывает пароли с нежелательными подстроками; nil
+	// отключает фильтр
+	Filter *ProfanityFilter
+}
+
+// GeneratePronounceableFiltered генерирует произносимый пароль, как
+// GeneratePronounceable, и перегенерирует его, пока он содержит подстроки
+// из filter (например, DefaultProfanityFilter). nil отключает фильтр.
+func GeneratePronounceableFiltered(length int, filter *ProfanityFilter) (string, error) {
+	return GeneratePronounceableWithOptions(length, PronounceableOptions{Filter: filter})
+}
+
+// GeneratePronounceableWithOptions генерирует произносимый пароль длины
+// length с параметрами opts
+func GeneratePronounceableWithOptions(length int, opts PronounceableOptions) (string, error) {
+	if length <= 0 {
+		return "", errorf(ErrInvalidConfig, msg(msgLengthNotPositive))
+	}
+
+	return generateFiltered(opts.Filter, func() (string, error) {
+		if !opts.RequireDigit {
+			return pronounceable(length)
+		}
+		return pronounceableWithDigit(length)
+	})
+}
+
+// pronounceable генерирует один произносимый пароль без фильтрации
+func pronounceable(length int) (string, error) {
+	groups := [2][]rune{[]rune(consonants), []rune(vowels)}
+
+	start, err := secureRandomInt(2)
+	if err != nil {
+		return "", err
+	}
+
+	result := make([]rune, length)
+	for i := range result {
+		group := groups[(start+i)%2]
+		idx, err := secureRandomInt(len(group))
+		if err != nil {
+			return "", err
+		}
+		result[i] = group[idx]
+	}
+
+	return string(result), nil
+}
+
+// pronounceableWithDigit генерирует произносимую основу длины length-1 и
+// вставляет в случайную позицию случайную цифру
+func pronounceableWithDigit(length int) (string, error) {
+	letters, err := pronounceable(length - 1)
+	if err != nil {
+		return "", err
+	}
+
+	pos, err := secureRandomInt(length)
+	if err != nil {
+		return "", err
+	}
+	idx, err := secureRandomInt(len(digits))
+	if err != nil {
+		return "", err
+	}
+
+	return letters[:pos] + string(digits[idx]) + letters[pos:], nil
+}
+
+// GenerateSyllabic генерирует пароль из blocks слогов вида
+// согласная-гласная-согласная, разделённых случайными цифрами,
+// например "bok7gaz3mup"
+func GenerateSyllabic(blocks int) (string, error) {
+	return GenerateSyllabicFiltered(blocks, nil)
+}
+
+// GenerateSyllabicFiltered генерирует слоговый пароль, как GenerateSyllabic,
+// отбрасывая результаты с подстроками из filter. nil отключает фильтр.
+func GenerateSyllabicFiltered(blocks int, filter *ProfanityFilter) (string, error) {
+	if blocks <= 0 {
+		return "", errorf(ErrInvalidConfig, msg(msgSyllablesNotPositive))
+	}
+
+	return generateFiltered(filter, func() (string, error) {
+		return syllabic(blocks)
+	})
+}
+
+// syllabic генерирует один слоговый пароль без фильтрации
+func syllabic(blocks int) (string, error) {
+	pattern := [3]string{consonants, vowels, consonants}
+	result := make([]rune, 0, blocks*4-1)
+
+	pick := func(set string) error {
+		runes := []rune(set)
+		idx, err := secureRandomInt(len(runes))
+		if err != nil {
+			return err
+		}
+		result = append(result, runes[idx])
+		return nil
+	}
+
+	for block := 0; block < blocks; block++ {
+		if block > 0 {
+			if err := pick(digits); err != nil {
+				return "", err
+			}
+		}
+		for _, set := range pattern {
+			if err := pick(set); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	return string(result), nil
+}
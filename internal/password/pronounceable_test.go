@@ -0,0 +1,112 @@
+package password
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestGeneratePronounceable(t *testing.T) {
+	for _, length := range []int{1, 2, 8, 15} {
+		for i := 0; i < 20; i++ {
+			password, err := GeneratePronounceable(length)
+			if err != nil {
+				t.Fatalf("GeneratePronounceable(%d) failed: %v", length, err)
+			}
+
+			if len(password) != length {
+				t.Errorf("Password %q length = %d, want %d", password, len(password), length)
+			}
+
+			// Соседние символы должны принадлежать разным группам
+			for j := 1; j < len(password); j++ {
+				prevVowel := strings.IndexByte(vowels, password[j-1]) >= 0
+				currVowel := strings.IndexByte(vowels, password[j]) >= 0
+				if prevVowel == currVowel {
+					t.Errorf("Password %q breaks consonant/vowel alternation at index %d", password, j)
+				}
+			}
+
+			for _, char := range password {
+				if !strings.ContainsRune(consonants+vowels, char) {
+					t.Errorf("Password %q contains unexpected character %c", password, char)
+				}
+			}
+		}
+	}
+}
+
+func TestGeneratePronounceableInvalidLength(t *testing.T) {
+	if _, err := GeneratePronounceable(0); err == nil {
+		t.Error("Expected error for zero length, got none")
+	}
+}
+
+func TestGeneratePronounceableRequireDigit(t *testing.T) {
+	for _, length := range []int{1, 2, 8, 15} {
+		positions := make(map[int]bool)
+		for i := 0; i < 50; i++ {
+			password, err := GeneratePronounceableWithOptions(length, PronounceableOptions{RequireDigit: true})
+			if err != nil {
+				t.Fatalf("GeneratePronounceableWithOptions(%d) failed: %v", length, err)
+			}
+
+			if len(password) != length {
+				t.Errorf("Password %q length = %d, want %d", password, len(password), length)
+			}
+
+			pos := strings.IndexAny(password, digits)
+			if pos < 0 || strings.LastIndexAny(password, digits) != pos {
+				t.Fatalf("Password %q must contain exactly one digit", password)
+			}
+			positions[pos] = true
+
+			// Без цифры остаётся обычный произносимый пароль
+			letters := password[:pos] + password[pos+1:]
+			for j := 1; j < len(letters); j++ {
+				prevVowel := strings.IndexByte(vowels, letters[j-1]) >= 0
+				currVowel := strings.IndexByte(vowels, letters[j]) >= 0
+				if prevVowel == currVowel {
+					t.Errorf("Password %q breaks consonant/vowel alternation around the digit", password)
+				}
+			}
+		}
+
+		if length > 1 && len(positions) < 2 {
+			t.Errorf("Digit always at the same position for length %d", length)
+		}
+	}
+}
+
+func TestGeneratePronounceableWithOptionsInvalidLength(t *testing.T) {
+	if _, err := GeneratePronounceableWithOptions(0, PronounceableOptions{RequireDigit: true}); err == nil {
+		t.Error("Expected error for zero length, got none")
+	}
+}
+
+func TestGenerateSyllabic(t *testing.T) {
+	for _, blocks := range []int{1, 3, 5} {
+		structure := regexp.MustCompile(fmt.Sprintf("^([%[1]s][%[2]s][%[1]s][0-9]){%[3]d}[%[1]s][%[2]s][%[1]s]$", consonants, vowels, blocks-1))
+
+		for i := 0; i < 20; i++ {
+			password, err := GenerateSyllabic(blocks)
+			if err != nil {
+				t.Fatalf("GenerateSyllabic(%d) failed: %v", blocks, err)
+			}
+
+			if want := blocks*4 - 1; len(password) != want {
+				t.Errorf("Password %q length = %d, want %d", password, len(password), want)
+			}
+			if !structure.MatchString(password) {
+				t.Errorf("Password %q does not match CVC block structure for %d blocks", password, blocks)
+			}
+		}
+	}
+}
+
+func TestGenerateSyllabicInvalidBlocks(t *testing.T) {
+	if _, err := GenerateSyllabic(0); err == nil {
+		t.Error("Expected error for zero blocks, got none")
+	}
+}
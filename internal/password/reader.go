@@ -0,0 +1,30 @@
+package password
+
+import (
+	"bytes"
+	"io"
+)
+
+// NewGeneratorWithReader создаёт генератор, который берёт случайные байты из r
+// вместо crypto/rand. В отличие от NewSeededGenerator, числа по-прежнему
+// получаются через rand.Int, поэтому проверяется тот же путь, что и в боевом
+// генераторе. Ошибка чтения из r возвращается как ErrRandom.
+//
+// ВНИМАНИЕ: пароли надёжны ровно настолько, насколько непредсказуем r.
+// С DeterministicReader генератор предназначен только для тестов.
+func NewGeneratorWithReader(config Config, r io.Reader) (*Generator, error) {
+	gen, err := NewGenerator(config)
+	if err != nil {
+		return nil, err
+	}
+
+	gen.random = r
+	return gen, nil
+}
+
+// DeterministicReader возвращает источник, который отдаёт копию data и затем
+// io.EOF. Одинаковые байты дают одинаковые пароли, что позволяет проверять
+// точный результат генерации в тестах.
+func DeterministicReader(data []byte) io.Reader {
+	return bytes.NewReader(bytes.Clone(data))
+}
@@ -0,0 +1,152 @@
+package password
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestGeneratorWithDeterministicReader(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		stream []byte
+		want   string
+	}{
+		{
+			// rand.Int(4): 0x02 -> 'c'; rand.Int(3): 0x07 отбрасывается (3 >= 3),
+			// 0x01 -> 'b' из оставшихся "abd"; перемешивание rand.Int(2): 0x00 меняет местами
+			name:   "через rand.Int",
+			config: Config{Length: 2, CustomChars: "abcd"},
+			stream: []byte{0x02, 0x07, 0x01, 0x00},
+			want:   "bc",
+		},
+		{
+			// Быстрый путь цифр с источником не используется: rand.Int(10)
+			// отбрасывает 15, перемешивание 3, 2, 1 оставляет порядок
+			name:   "PIN",
+			config: Config{Length: 4, UseDigits: true, AllowRepeats: true},
+			stream: []byte{3, 7, 15, 1, 9, 3, 2, 1},
+			want:   "3719",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen, err := NewGeneratorWithReader(tt.config, DeterministicReader(tt.stream))
+			if err != nil {
+				t.Fatalf("NewGeneratorWithReader() failed: %v", err)
+			}
+
+			got, err := gen.Generate()
+			if err != nil {
+				t.Fatalf("Generate() failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Generate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeterministicReaderExhausted(t *testing.T) {
+	// Байт хватает только на первый символ, дальше источник возвращает io.EOF
+	gen, err := NewGeneratorWithReader(Config{Length: 2, CustomChars: "abcd"}, DeterministicReader([]byte{0x02}))
+	if err != nil {
+		t.Fatalf("NewGeneratorWithReader() failed: %v", err)
+	}
+
+	if _, err := gen.Generate(); !errors.Is(err, ErrRandom) {
+		t.Errorf("Generate() error = %v, want ErrRandom", err)
+	}
+}
+
+func TestDeterministicReaderCopiesData(t *testing.T) {
+	data := []byte{0x02, 0x07, 0x01, 0x00}
+	r := DeterministicReader(data)
+	data[0] = 0x03
+
+	gen, err := NewGeneratorWithReader(Config{Length: 2, CustomChars: "abcd"}, r)
+	if err != nil {
+		t.Fatalf("NewGeneratorWithReader() failed: %v", err)
+	}
+	if got, err := gen.Generate(); err != nil || got != "bc" {
+		t.Errorf("Generate() = %q, %v, want \"bc\" regardless of later changes to data", got, err)
+	}
+}
+
+// flakyReader возвращает ошибку первые failures чтений, затем читает из r
+type flakyReader struct {
+	failures int
+	reads    int
+	r        io.Reader
+}
+
+func (f *flakyReader) Read(p []byte) (int, error) {
+	f.reads++
+	if f.failures > 0 {
+		f.failures--
+		return 0, errEntropyStarved
+	}
+	return f.r.Read(p)
+}
+
+var errEntropyStarved = errors.New("entropy temporarily unavailable")
+
+func TestRandomRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		wantErr  bool
+	}{
+		{name: "без сбоев", failures: 0, wantErr: false},
+		{name: "два сбоя подряд", failures: 2, wantErr: false},
+		{name: "сбоев больше лимита", failures: 3, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := &flakyReader{failures: tt.failures, r: DeterministicReader([]byte{5})}
+
+			got, err := randomIntFrom(src, 7)
+			if tt.wantErr {
+				if !errors.Is(err, ErrRandom) || !errors.Is(err, errEntropyStarved) {
+					t.Errorf("randomIntFrom() error = %v, want ErrRandom wrapping the reader error", err)
+				}
+				if src.reads != RandomRetries+1 {
+					t.Errorf("reader called %d times, want %d", src.reads, RandomRetries+1)
+				}
+				return
+			}
+			if err != nil || got != 5 {
+				t.Errorf("randomIntFrom() = %d, %v, want 5 after %d failures", got, err, tt.failures)
+			}
+		})
+	}
+}
+
+func TestGeneratorRecoversFromReaderFailures(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		stream []byte
+		want   string
+	}{
+		{name: "через rand.Int", config: Config{Length: 2, CustomChars: "abcd"}, stream: []byte{0x02, 0x07, 0x01, 0x00}, want: "bc"},
+		{name: "PIN", config: Config{Length: 4, UseDigits: true, AllowRepeats: true}, stream: []byte{3, 7, 15, 1, 9, 3, 2, 1}, want: "3719"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := &flakyReader{failures: 2, r: DeterministicReader(tt.stream)}
+			gen, err := NewGeneratorWithReader(tt.config, src)
+			if err != nil {
+				t.Fatalf("NewGeneratorWithReader() failed: %v", err)
+			}
+
+			if got, err := gen.Generate(); err != nil || got != tt.want {
+				t.Errorf("Generate() = %q, %v, want %q after transient failures", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package password
+
+import "unicode/utf8"
+
+// RegenerateInto записывает новый пароль в buf целиком, вместе с префиксом
+// и суффиксом, - для сервисов, которые меняют секрет на месте и сами
+// управляют памятью. Длина случайной части - len(buf) минус префикс и
+// суффикс, она проверяется так же, как в GenerateLength. Все символы
+// набора должны занимать один байт (ASCII), иначе возвращается
+// ErrInvalidConfig.
+//
+// Промежуточные символы обнуляются. Как и NthPassword, пароль не
+// запоминается и не проверяется на уникальность. При ошибке случайная
+// часть buf обнуляется.
+func (g *Generator) RegenerateInto(buf []byte) error {
+	length := len(buf) - len(g.prefix) - len(g.suffix)
+	if length < 0 {
+		return errorf(ErrInvalidConfig, msg(msgBufferBelowAffixes), len(buf), len(g.prefix)+len(g.suffix))
+	}
+	for _, r := range g.charset {
+		if r >= utf8.RuneSelf {
+			return errorf(ErrInvalidConfig, msg(msgBufferNonASCII), r)
+		}
+	}
+	if err := g.checkLength(length); err != nil {
+		return err
+	}
+
+	copy(buf, g.prefix)
+	copy(buf[len(buf)-len(g.suffix):], g.suffix)
+	core := buf[len(g.prefix) : len(g.prefix)+length]
+
+	for attempt := 0; attempt < g.maxAttempts; attempt++ {
+		g.attempts++
+
+		runes, err := g.generateRunes(length)
+		if err != nil {
+			clear(core)
+			return err
+		}
+		for i, r := range runes {
+			core[i] = byte(r)
+		}
+		clear(runes)
+
+		if !g.satisfiesRules(string(core)) {
+			continue
+		}
+		if g.accept == nil || g.accept(string(buf)) {
+			return nil
+		}
+	}
+
+	clear(core)
+	return errorf(ErrCharsetExhausted, msg(msgRulesAttemptsExhausted), g.maxAttempts)
+}
@@ -0,0 +1,139 @@
+package password
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRegenerateInto(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		size   int
+	}{
+		{"длина из буфера", Config{Length: 16, UseDigits: true, UseLower: true, UseUpper: true, UseSymbols: true}, 24},
+		{"только цифры с повторами", Config{Length: 6, UseDigits: true, AllowRepeats: true}, 32},
+		{"правила", Config{Length: 12, UseLower: true, UseUpper: true, NoLeadingDigit: true, MaxSequential: 2, AlternateClasses: true}, 12},
+		{"префикс и суффикс", Config{Length: 8, UseDigits: true, UseLower: true, Prefix: "tmp-", Suffix: "!"}, 15},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen, err := NewGenerator(tt.config)
+			if err != nil {
+				t.Fatalf("NewGenerator() failed: %v", err)
+			}
+
+			// 0xFF не входит ни в один набор: оставшийся байт сразу заметен
+			buf := bytes.Repeat([]byte{0xFF}, tt.size)
+			if err := gen.RegenerateInto(buf); err != nil {
+				t.Fatalf("RegenerateInto() failed: %v", err)
+			}
+			first := string(buf)
+
+			core := buf[len(tt.config.Prefix) : len(buf)-len(tt.config.Suffix)]
+			for i, b := range core {
+				if !strings.ContainsRune(gen.Charset(), rune(b)) {
+					t.Errorf("byte %d = %#x is not in the charset", i, b)
+				}
+			}
+			if !strings.HasPrefix(first, tt.config.Prefix) || !strings.HasSuffix(first, tt.config.Suffix) {
+				t.Errorf("buffer %q lacks prefix %q or suffix %q", first, tt.config.Prefix, tt.config.Suffix)
+			}
+
+			// Пароль проходит политику генератора при длине из буфера
+			override := *gen
+			override.length = len(core)
+			if err := override.Validate(first); err != nil {
+				t.Errorf("Validate(%q) = %v", first, err)
+			}
+
+			if err := gen.RegenerateInto(buf); err != nil {
+				t.Fatalf("RegenerateInto() failed: %v", err)
+			}
+			if string(buf) == first {
+				t.Errorf("buffer %q was not regenerated", first)
+			}
+		})
+	}
+}
+
+func TestRegenerateIntoErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		size   int
+	}{
+		{"не ASCII", Config{Length: 8, UseLower: true, UseEmoji: true}, 8},
+		{"длиннее набора без повторов", Config{Length: 8, UseDigits: true}, 11},
+		{"пустой буфер", Config{Length: 8, UseDigits: true}, 0},
+		{"короче префикса", Config{Length: 8, UseDigits: true, Prefix: "tmp-"}, 3},
+		{"меньше обязательных наборов", Config{Length: 8, UseDigits: true, UseLower: true, UseUpper: true}, 2},
+		{"чередование невыполнимо", Config{Length: 8, UseDigits: true, UseLower: true, AlternateClasses: true}, 22},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen, err := NewGenerator(tt.config)
+			if err != nil {
+				t.Fatalf("NewGenerator() failed: %v", err)
+			}
+
+			buf := bytes.Repeat([]byte{'x'}, tt.size)
+			if err := gen.RegenerateInto(buf); !errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("RegenerateInto() error = %v, want ErrInvalidConfig", err)
+			}
+		})
+	}
+}
+
+func TestRegenerateIntoClearsOnFailure(t *testing.T) {
+	gen, err := NewGenerator(Config{
+		Length:    4,
+		UseDigits: true,
+		Accept:    func(string) bool { return false },
+	})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	buf := []byte("1234")
+	if err := gen.RegenerateInto(buf); !errors.Is(err, ErrCharsetExhausted) {
+		t.Fatalf("RegenerateInto() error = %v, want ErrCharsetExhausted", err)
+	}
+	if !bytes.Equal(buf, make([]byte, 4)) {
+		t.Errorf("buffer = %q, want zeroed", buf)
+	}
+}
+
+func TestRegenerateIntoAcceptKeepsString(t *testing.T) {
+	// Accept получает копию буфера: сохранённая строка не меняется при
+	// повторном использовании buf
+	var seen []string
+	gen, err := NewGenerator(Config{
+		Length:   12,
+		UseLower: true,
+		Accept:   func(password string) bool { seen = append(seen, password); return true },
+	})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	buf := make([]byte, 12)
+	var want []string
+	for i := 0; i < 3; i++ {
+		if err := gen.RegenerateInto(buf); err != nil {
+			t.Fatalf("RegenerateInto() failed: %v", err)
+		}
+		want = append(want, string(buf))
+	}
+	clear(buf)
+
+	for i := range want {
+		if seen[i] != want[i] {
+			t.Errorf("Accept string %d = %q, want %q", i, seen[i], want[i])
+		}
+	}
+}
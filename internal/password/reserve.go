@@ -0,0 +1,30 @@
+package password
+
+// Reserve заранее генерирует n уникальных паролей и откладывает их в пул
+// генератора, чтобы выдавать через Next без затрат на генерацию в момент
+// запроса. Пароли пула учитываются в уникальности так же, как выданные:
+// Generate и следующие вызовы Reserve их не повторят. Повторный вызов
+// добавляет пароли в конец пула. При ошибке пул не меняется.
+func (g *Generator) Reserve(n int) error {
+	passwords, err := g.GenerateUnique(n)
+	if err != nil {
+		return err
+	}
+
+	g.reserved = append(g.reserved, passwords...)
+	return nil
+}
+
+// Next выдаёт следующий пароль из пула Reserve в порядке генерации.
+// ok=false означает, что пул пуст.
+func (g *Generator) Next() (string, bool) {
+	if len(g.reserved) == 0 {
+		return "", false
+	}
+
+	password := g.reserved[0]
+	// Выданный пароль не остаётся в памяти пула
+	g.reserved[0] = ""
+	g.reserved = g.reserved[1:]
+	return password, true
+}
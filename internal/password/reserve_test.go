@@ -0,0 +1,80 @@
+package password
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestReserveAndNext(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 8, UseLower: true, UseDigits: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	if _, ok := gen.Next(); ok {
+		t.Fatal("Next() on empty pool returned ok = true")
+	}
+
+	if err := gen.Reserve(20); err != nil {
+		t.Fatalf("Reserve(20) failed: %v", err)
+	}
+	if err := gen.Reserve(5); err != nil {
+		t.Fatalf("Reserve(5) failed: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	for i := 0; i < 25; i++ {
+		pwd, ok := gen.Next()
+		if !ok {
+			t.Fatalf("Next() reported exhaustion after %d of 25 passwords", i)
+		}
+		if err := gen.Validate(pwd); err != nil {
+			t.Errorf("Validate(%q) failed: %v", pwd, err)
+		}
+		if seen[pwd] {
+			t.Errorf("Duplicate password %q", pwd)
+		}
+		seen[pwd] = true
+	}
+
+	if pwd, ok := gen.Next(); ok || pwd != "" {
+		t.Errorf("Next() on drained pool = %q, %v, want \"\", false", pwd, ok)
+	}
+
+	// Отложенные пароли учитываются в уникальности обычной генерации
+	more, err := gen.GenerateUnique(50)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+	for _, pwd := range more {
+		if seen[pwd] {
+			t.Errorf("GenerateUnique() repeated reserved password %q", pwd)
+		}
+	}
+}
+
+func TestReserveErrors(t *testing.T) {
+	// 2 цифры без повторов: 90 комбинаций
+	gen, err := NewGenerator(Config{Length: 2, UseDigits: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	if err := gen.Reserve(0); !errors.Is(err, ErrInvalidCount) {
+		t.Errorf("Reserve(0) error = %v, want ErrInvalidCount", err)
+	}
+	if err := gen.Reserve(91); !errors.Is(err, ErrCharsetExhausted) {
+		t.Errorf("Reserve(91) error = %v, want ErrCharsetExhausted", err)
+	}
+	if _, ok := gen.Next(); ok {
+		t.Error("Next() returned a password after failed Reserve")
+	}
+
+	// Клон не разделяет пул
+	if err := gen.Reserve(3); err != nil {
+		t.Fatalf("Reserve(3) failed: %v", err)
+	}
+	if _, ok := gen.Clone().Next(); ok {
+		t.Error("Clone().Next() returned a password from the parent pool")
+	}
+}
@@ -0,0 +1,98 @@
+package password
+
+import (
+	"math"
+	"strings"
+)
+
+// GenerateRotated генерирует уникальный пароль для ротации: новый пароль
+// старается не содержать символов предыдущего previous. Символы previous
+// исключаются из набора генератора, если без них остаётся достаточно
+// символов; набор, целиком состоящий из символов previous, сохраняется,
+// чтобы обязательный символ из него оставался возможен. Для маленьких
+// наборов правило не строгое: первые половину лимита попыток отбрасываются
+// кандидаты с пересечением больше неизбежного, затем принимается любой.
+// Префикс и суффикс в сравнении не участвуют.
+func (g *Generator) GenerateRotated(previous string) (string, error) {
+	previous = strings.TrimSuffix(strings.TrimPrefix(previous, g.prefix), g.suffix)
+	old := []rune(previous)
+	if !containsAnyRune(g.charset, old) {
+		return g.Generate()
+	}
+
+	override := *g
+	override.digitsOnly = false
+	override.charset = nil
+	override.charsets = make([][]rune, len(g.charsets))
+	for i, group := range g.charsets {
+		fresh := excludeRunes(group, previous)
+		if len(fresh) == 0 {
+			fresh = group
+		}
+		override.charsets[i] = fresh
+		override.charset = append(override.charset, fresh...)
+	}
+
+	maxLength := g.length
+	if maxLength == 0 {
+		maxLength = g.maxLength
+	}
+
+	if override.fits(maxLength) {
+		password, err := override.Generate()
+		g.attempts = override.attempts
+		return password, err
+	}
+
+	// Новых символов не хватает: пересечение не меньше числа недостающих
+	fresh := make([][]rune, len(g.charsets))
+	for i, group := range g.charsets {
+		fresh[i] = excludeRunes(group, previous)
+	}
+	unavoidable := maxLength - g.capacity(fresh)
+	tooSimilar := func(candidate string) bool {
+		core := strings.TrimSuffix(strings.TrimPrefix(candidate, g.prefix), g.suffix)
+		return countRunes([]rune(core), old) > unavoidable
+	}
+	return g.generateAvoiding(tooSimilar, g.maxAttempts/2)
+}
+
+// fits сообщает, проходит ли генератор с урезанными наборами те же проверки
+// длины, что NewGenerator: без повторов, с пределом вхождений, с весами,
+// с минимумами CustomGroups и с чередованием классов. Число обязательных
+// символов от урезания наборов не меняется.
+func (g *Generator) fits(length int) bool {
+	if g.capacity(g.charsets) < length {
+		return false
+	}
+	if !g.allowRepeats && g.groupMins != nil {
+		for i, group := range g.charsets {
+			if g.groupMins[i] > len(group) {
+				return false
+			}
+		}
+	}
+	if g.alternateClasses && checkAlternateClasses(g.charsets, length, g.allowRepeats, g.maxOccur) != nil {
+		return false
+	}
+	return true
+}
+
+// capacity возвращает, сколько символов можно набрать из charsets с учётом
+// запрета повторов, предела вхождений и весов генератора
+func (g *Generator) capacity(charsets [][]rune) int {
+	total := 0
+	for _, group := range charsets {
+		total += len(group)
+	}
+
+	switch {
+	case g.maxOccur > 0:
+		return total * g.maxOccur
+	case g.allowRepeats:
+		return math.MaxInt
+	case g.weights != nil:
+		return min(total, weightedCapacity(charsets, g.weights, g.requireEachSet))
+	}
+	return total
+}
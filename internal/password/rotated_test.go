@@ -0,0 +1,143 @@
+package password
+
+import (
+	"strings"
+	"testing"
+)
+
+// overlap возвращает число символов candidate, встречающихся в previous
+func overlap(candidate, previous string) int {
+	return countRunes([]rune(candidate), []rune(previous))
+}
+
+func TestGenerateRotated(t *testing.T) {
+	config := Config{Length: 12, UseDigits: true, UseLower: true, UseUpper: true}
+
+	const runs = 30
+	rotatedTotal, plainTotal := 0, 0
+	for i := 0; i < runs; i++ {
+		gen, err := NewGenerator(config)
+		if err != nil {
+			t.Fatalf("NewGenerator() failed: %v", err)
+		}
+		previous, err := gen.Generate()
+		if err != nil {
+			t.Fatalf("Generate() failed: %v", err)
+		}
+
+		rotated, err := gen.GenerateRotated(previous)
+		if err != nil {
+			t.Fatalf("GenerateRotated() failed: %v", err)
+		}
+		if err := gen.Validate(rotated); err != nil {
+			t.Errorf("Validate(%q) failed: %v", rotated, err)
+		}
+		plain, err := gen.Generate()
+		if err != nil {
+			t.Fatalf("Generate() failed: %v", err)
+		}
+
+		// 62 символа на 12 позиций: новых символов хватает, и ни один набор
+		// не исчерпывается символами previous
+		if got := overlap(rotated, previous); got != 0 {
+			t.Errorf("GenerateRotated(%q) = %q shares %d characters, want 0", previous, rotated, got)
+		}
+		rotatedTotal += overlap(rotated, previous)
+		plainTotal += overlap(plain, previous)
+	}
+
+	if rotatedTotal >= plainTotal {
+		t.Errorf("rotated overlap %d is not below unconstrained overlap %d", rotatedTotal, plainTotal)
+	}
+}
+
+func TestGenerateRotatedSmallCharset(t *testing.T) {
+	// 8 цифр из 10 без повторов: новых цифр только две, поэтому пересечение
+	// не меньше 6
+	gen, err := NewGenerator(Config{Length: 8, UseDigits: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	for i := 0; i < 20; i++ {
+		previous, err := gen.Generate()
+		if err != nil {
+			t.Fatalf("Generate() failed: %v", err)
+		}
+		rotated, err := gen.GenerateRotated(previous)
+		if err != nil {
+			t.Fatalf("GenerateRotated() failed: %v", err)
+		}
+		if got := overlap(rotated, previous); got != 6 {
+			t.Errorf("GenerateRotated(%q) = %q shares %d characters, want 6", previous, rotated, got)
+		}
+	}
+}
+
+// Урезанные наборы проверяются теми же правилами, что и в NewGenerator: при
+// нехватке новых символов с учётом весов или предела вхождений генерация не
+// падает, а переходит к нестрогому правилу
+func TestGenerateRotatedLimitedCapacity(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   Config
+		previous string
+		want     int
+	}{
+		{
+			// 6 новых цифр и одна обязательная буква на 10 позиций
+			name:     "weights",
+			config:   Config{Length: 10, UseDigits: true, UseLower: true, Weights: map[string]int{"digits": 1}},
+			previous: "0123",
+			want:     3,
+		},
+		{
+			// 5 новых цифр по 2 вхождения на 20 позиций
+			name:     "max occurrences",
+			config:   Config{Length: 20, UseDigits: true, MaxCharOccurrences: 2},
+			previous: "01234",
+			want:     10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen, err := NewGenerator(tt.config)
+			if err != nil {
+				t.Fatalf("NewGenerator() failed: %v", err)
+			}
+
+			for i := 0; i < 10; i++ {
+				rotated, err := gen.GenerateRotated(tt.previous)
+				if err != nil {
+					t.Fatalf("GenerateRotated() failed: %v", err)
+				}
+				if err := gen.Validate(rotated); err != nil {
+					t.Errorf("Validate(%q) failed: %v", rotated, err)
+				}
+				if got := overlap(rotated, tt.previous); got != tt.want {
+					t.Errorf("GenerateRotated(%q) = %q shares %d characters, want %d", tt.previous, rotated, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateRotatedAffixes(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 6, UseLower: true, Prefix: "temp-", Suffix: "!"})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	previous := "temp-abcdef!"
+	rotated, err := gen.GenerateRotated(previous)
+	if err != nil {
+		t.Fatalf("GenerateRotated() failed: %v", err)
+	}
+	if !strings.HasPrefix(rotated, "temp-") || !strings.HasSuffix(rotated, "!") {
+		t.Fatalf("GenerateRotated() = %q, want prefix and suffix", rotated)
+	}
+	if strings.ContainsAny(rotated[len("temp-"):len(rotated)-1], "abcdef") {
+		t.Errorf("GenerateRotated(%q) = %q reuses characters of the previous password", previous, rotated)
+	}
+}
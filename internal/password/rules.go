@@ -0,0 +1,228 @@
+package password
+
+import "strings"
+
+// keyboardRows содержит ряды и диагонали клавиатуры, по которым строятся запрещённые серии
+var keyboardRows = []string{
+	"1234567890",
+	"qwertyuiop",
+	"asdfghjkl",
+	"zxcvbnm",
+	"!@#$%^&*()",
+	"1qaz", "2wsx", "3edc", "4rfv", "5tgb", "6yhn", "7ujm",
+}
+
+// keyboardRunLength - минимальная длина клавиатурной серии, считающейся слабой
+const keyboardRunLength = 4
+
+// keyboardSequences - все клавиатурные серии длины keyboardRunLength в обоих направлениях
+var keyboardSequences = buildKeyboardSequences()
+
+// buildKeyboardSequences собирает список запрещённых подстрок из keyboardRows
+func buildKeyboardSequences() []string {
+	var result []string
+	for _, row := range keyboardRows {
+		runes := []rune(row)
+		for i := 0; i+keyboardRunLength <= len(runes); i++ {
+			run := runes[i : i+keyboardRunLength]
+			reversed := make([]rune, len(run))
+			for j, r := range run {
+				reversed[len(run)-1-j] = r
+			}
+			result = append(result, string(run), string(reversed))
+		}
+	}
+	return result
+}
+
+// satisfiesRules проверяет, что кандидат удовлетворяет всем дополнительным правилам генератора
+func (g *Generator) satisfiesRules(password string) bool {
+	return g.checkRules(password) == nil
+}
+
+// checkRules возвращает описание первого нарушенного дополнительного правила
+// с причиной ErrPolicyViolation или nil
+func (g *Generator) checkRules(password string) error {
+	runes := []rune(password)
+
+	if g.maxConsecutive > 0 && longestRepeatRun(runes) > g.maxConsecutive {
+		return errorf(ErrPolicyViolation, msg(msgRuleConsecutive), g.maxConsecutive)
+	}
+
+	if g.maxSequential > 0 && longestSequentialRun(runes) > g.maxSequential {
+		return errorf(ErrPolicyViolation, msg(msgRuleSequential), g.maxSequential)
+	}
+
+	if g.avoidKeyboard && containsKeyboardSequence(password) {
+		return errorf(ErrPolicyViolation, msg(msgRuleKeyboard))
+	}
+
+	if g.avoidYears {
+		if year, ok := findYear(runes); ok {
+			return errorf(ErrPolicyViolation, msg(msgRuleYear), year)
+		}
+	}
+
+	if g.noLeadingDigit && len(runes) > 0 && strings.ContainsRune(digits, runes[0]) {
+		return errorf(ErrPolicyViolation, msg(msgRuleLeadingDigit))
+	}
+
+	if g.requireSymbolAtEnd && (len(runes) == 0 || !strings.ContainsRune(symbols, runes[len(runes)-1])) {
+		return errorf(ErrPolicyViolation, msg(msgRuleSymbolAtEnd))
+	}
+
+	if g.alternateClasses && g.hasAdjacentClass(runes) {
+		return errorf(ErrPolicyViolation, msg(msgRuleAdjacentClass))
+	}
+
+	if g.isBlocked(g.withAffixes(password)) {
+		return errorf(ErrPolicyViolation, msg(msgRuleBlocked))
+	}
+
+	return nil
+}
+
+// Диапазон лет, которые считаются слабыми подстроками
+const (
+	minYear = 1900
+	maxYear = 2099
+)
+
+// findYear возвращает первую подстроку из четырёх цифр, похожую на год
+// в диапазоне minYear-maxYear
+func findYear(runes []rune) (string, bool) {
+	for i := 0; i+4 <= len(runes); i++ {
+		year := 0
+		for _, r := range runes[i : i+4] {
+			if r < '0' || r > '9' {
+				year = -1
+				break
+			}
+			year = year*10 + int(r-'0')
+		}
+		if year >= minYear && year <= maxYear {
+			return string(runes[i : i+4]), true
+		}
+	}
+	return "", false
+}
+
+// moveSymbolToEnd переставляет случайно выбранный спецсимвол пароля в последнюю
+// позицию. Остальные символы уже перемешаны, поэтому их порядок остаётся случайным.
+// Пароль без спецсимвола остаётся как есть: checkRules отклонит его, и кандидат
+// будет сгенерирован заново.
+func (g *Generator) moveSymbolToEnd(password []rune) error {
+	var positions []int
+	for i, r := range password {
+		if strings.ContainsRune(symbols, r) {
+			positions = append(positions, i)
+		}
+	}
+	if len(positions) == 0 {
+		return nil
+	}
+
+	idx, err := g.randomInt(len(positions))
+	if err != nil {
+		return err
+	}
+
+	last := len(password) - 1
+	password[positions[idx]], password[last] = password[last], password[positions[idx]]
+	return nil
+}
+
+// longestRepeatRun возвращает длину самой длинной серии одинаковых символов подряд
+func longestRepeatRun(runes []rune) int {
+	if len(runes) == 0 {
+		return 0
+	}
+
+	longest, current := 1, 1
+	for i := 1; i < len(runes); i++ {
+		if runes[i] == runes[i-1] {
+			current++
+		} else {
+			current = 1
+		}
+		if current > longest {
+			longest = current
+		}
+	}
+	return longest
+}
+
+// longestSequentialRun возвращает длину самой длинной возрастающей или убывающей
+// последовательности соседних символов, например "abc" или "321"
+func longestSequentialRun(runes []rune) int {
+	if len(runes) == 0 {
+		return 0
+	}
+
+	longest, ascending, descending := 1, 1, 1
+	for i := 1; i < len(runes); i++ {
+		if runes[i] == runes[i-1]+1 {
+			ascending++
+		} else {
+			ascending = 1
+		}
+		if runes[i] == runes[i-1]-1 {
+			descending++
+		} else {
+			descending = 1
+		}
+		longest = max(longest, ascending, descending)
+	}
+	return longest
+}
+
+// containsKeyboardSequence проверяет (без учёта регистра), содержит ли пароль клавиатурную серию
+func containsKeyboardSequence(password string) bool {
+	lowered := strings.ToLower(password)
+	for _, seq := range keyboardSequences {
+		if strings.Contains(lowered, seq) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasNonDigit проверяет, есть ли в наборе хотя бы один символ, отличный от цифры
+func hasNonDigit(charset []rune) bool {
+	for _, r := range charset {
+		if !strings.ContainsRune(digits, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildBlocklist собирает множество запрещённых паролей; при ignoreCase
+// пароли приводятся к нижнему регистру
+func buildBlocklist(list []string, ignoreCase bool) map[string]struct{} {
+	if len(list) == 0 {
+		return nil
+	}
+
+	blocked := make(map[string]struct{}, len(list))
+	for _, password := range list {
+		if ignoreCase {
+			password = strings.ToLower(password)
+		}
+		blocked[password] = struct{}{}
+	}
+	return blocked
+}
+
+// isBlocked проверяет, входит ли пароль в список запрещённых
+func (g *Generator) isBlocked(password string) bool {
+	if g.blocklist == nil {
+		return false
+	}
+
+	if g.blocklistIgnoreCase {
+		password = strings.ToLower(password)
+	}
+	_, blocked := g.blocklist[password]
+	return blocked
+}
@@ -0,0 +1,540 @@
+package password
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLongestRepeatRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		want     int
+	}{
+		{name: "пустая строка", password: "", want: 0},
+		{name: "без повторов", password: "abc", want: 1},
+		{name: "тройной повтор", password: "aaa1234", want: 3},
+		{name: "повтор в конце", password: "ab11", want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := longestRepeatRun([]rune(tt.password)); got != tt.want {
+				t.Errorf("longestRepeatRun(%q) = %d, want %d", tt.password, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLongestSequentialRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		want     int
+	}{
+		{name: "пустая строка", password: "", want: 0},
+		{name: "без последовательностей", password: "a1b", want: 1},
+		{name: "возрастающая", password: "xabcd", want: 4},
+		{name: "убывающая", password: "q321", want: 3},
+		{name: "повтор не последовательность", password: "aaa", want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := longestSequentialRun([]rune(tt.password)); got != tt.want {
+				t.Errorf("longestSequentialRun(%q) = %d, want %d", tt.password, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateMaxConsecutive(t *testing.T) {
+	config := Config{
+		Length:         30,
+		UseDigits:      true,
+		AllowRepeats:   true,
+		MaxConsecutive: 2,
+	}
+
+	gen, err := NewGenerator(config)
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	passwords, err := gen.GenerateUnique(500)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+
+	for _, password := range passwords {
+		for _, d := range digits {
+			if strings.Contains(password, strings.Repeat(string(d), 3)) {
+				t.Errorf("Password %q contains triple repeat of %c", password, d)
+			}
+		}
+	}
+}
+
+func TestGenerateMaxSequential(t *testing.T) {
+	config := Config{
+		Length:        8,
+		UseDigits:     true,
+		MaxSequential: 2,
+	}
+
+	gen, err := NewGenerator(config)
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	passwords, err := gen.GenerateUnique(200)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+
+	for _, password := range passwords {
+		if run := longestSequentialRun([]rune(password)); run > 2 {
+			t.Errorf("Password %q contains sequential run of %d", password, run)
+		}
+	}
+}
+
+func TestValidateConfigRuleLimits(t *testing.T) {
+	if err := validateConfig(Config{Length: 5, UseDigits: true, MaxConsecutive: -1}); err == nil {
+		t.Error("Expected error for negative MaxConsecutive, got none")
+	}
+
+	if err := validateConfig(Config{Length: 5, UseDigits: true, MaxSequential: -1}); err == nil {
+		t.Error("Expected error for negative MaxSequential, got none")
+	}
+}
+
+func TestContainsKeyboardSequence(t *testing.T) {
+	tests := []struct {
+		password string
+		want     bool
+	}{
+		{password: "xQwErz", want: true},
+		{password: "9ASDF", want: true},
+		{password: "a4321b", want: true},
+		{password: "zaq1!", want: true},
+		{password: "qwe9rty", want: false},
+		{password: "Xk9mPq2v", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := containsKeyboardSequence(tt.password); got != tt.want {
+			t.Errorf("containsKeyboardSequence(%q) = %v, want %v", tt.password, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateAvoidKeyboardSequences(t *testing.T) {
+	// Маленький набор из клавиатурных рядов делает серии вероятными
+	config := Config{
+		Length:                 6,
+		CustomChars:            "qwertasdfg",
+		AvoidKeyboardSequences: true,
+	}
+
+	gen, err := NewGenerator(config)
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	passwords, err := gen.GenerateUnique(1000)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+
+	for _, password := range passwords {
+		for _, seq := range keyboardSequences {
+			if strings.Contains(strings.ToLower(password), seq) {
+				t.Errorf("Password %q contains keyboard sequence %q", password, seq)
+			}
+		}
+	}
+}
+
+func TestGenerateNoLeadingDigit(t *testing.T) {
+	// Цифры и одна буква: без правила большинство паролей начинались бы с цифры
+	config := Config{
+		Length:         4,
+		UseDigits:      true,
+		CustomChars:    "xyz",
+		NoLeadingDigit: true,
+	}
+
+	gen, err := NewGenerator(config)
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	passwords, err := gen.GenerateUnique(300)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+
+	for _, password := range passwords {
+		if strings.ContainsRune(digits, rune(password[0])) {
+			t.Errorf("Password %q starts with a digit", password)
+		}
+		// Правило обязательного присутствия наборов по-прежнему действует
+		if !strings.ContainsAny(password, digits) {
+			t.Errorf("Password %q has no digit", password)
+		}
+	}
+}
+
+func TestNoLeadingDigitDigitsOnly(t *testing.T) {
+	if _, err := NewGenerator(Config{Length: 4, UseDigits: true, NoLeadingDigit: true}); err == nil {
+		t.Error("Expected error for digits-only config with NoLeadingDigit, got none")
+	}
+
+	if _, err := NewGenerator(Config{Length: 4, UseDigits: true, UseLower: true, ExcludeChars: lower, NoLeadingDigit: true}); err == nil {
+		t.Error("Expected error when exclusions leave only digits, got none")
+	}
+}
+
+func TestGenerateBlocklist(t *testing.T) {
+	// Цифры длины 2: 90 комбинаций, из них 4 запрещены
+	blocked := []string{"12", "21", "00", "98"}
+	gen, err := NewGenerator(Config{Length: 2, UseDigits: true, Blocklist: blocked})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	passwords, err := gen.GenerateUnique(85)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+
+	for _, password := range passwords {
+		for _, b := range blocked {
+			if password == b {
+				t.Errorf("Blocked password %q was generated", password)
+			}
+		}
+	}
+}
+
+func TestGenerateBlocklistIgnoreCase(t *testing.T) {
+	blocked := []string{"AB", "Ba", "cd"}
+
+	gen, err := NewGenerator(Config{Length: 2, CustomChars: "abcdABCD", Blocklist: blocked, BlocklistIgnoreCase: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	passwords, err := gen.GenerateUnique(40)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+
+	for _, password := range passwords {
+		for _, b := range blocked {
+			if strings.EqualFold(password, b) {
+				t.Errorf("Password %q matches blocked %q ignoring case", password, b)
+			}
+		}
+	}
+
+	// С учётом регистра запрещён только точный вариант
+	gen, err = NewGenerator(Config{Length: 2, CustomChars: "aAbB", Blocklist: []string{"ab"}})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+	if !gen.isBlocked("ab") || gen.isBlocked("AB") {
+		t.Error("Exact-match blocklist should block only \"ab\"")
+	}
+}
+
+func TestGenerateRequireSymbolAtEnd(t *testing.T) {
+	configs := []Config{
+		{Length: 12, UseDigits: true, UseLower: true, UseUpper: true, UseSymbols: true, RequireSymbolAtEnd: true},
+		{Length: 20, UseLower: true, UseSymbols: true, AllowRepeats: true, RequireSymbolAtEnd: true},
+		{Length: 16, UseLower: true, UseSymbols: true, Weights: map[string]int{"lower": 1}, RequireSymbolAtEnd: true},
+	}
+
+	for _, config := range configs {
+		gen, err := NewGenerator(config)
+		if err != nil {
+			t.Fatalf("NewGenerator() failed: %v", err)
+		}
+
+		passwords, err := gen.GenerateUnique(300)
+		if err != nil {
+			t.Fatalf("GenerateUnique() failed: %v", err)
+		}
+
+		for _, password := range passwords {
+			if !strings.ContainsRune(symbols, rune(password[len(password)-1])) {
+				t.Errorf("Password %q does not end with a symbol", password)
+			}
+		}
+	}
+}
+
+// Набор symbols обязателен при RequireSymbolAtEnd, даже если MinClasses или
+// RequireEachSet = false не требуют символа из каждого набора
+func TestRequireSymbolAtEndWithOptionalSets(t *testing.T) {
+	noEachSet := false
+	configs := []Config{
+		{Length: 8, UseDigits: true, UseLower: true, UseUpper: true, UseSymbols: true, MinClasses: 1, RequireSymbolAtEnd: true},
+		{Length: 8, UseDigits: true, UseLower: true, UseUpper: true, UseSymbols: true, MinClasses: 2, RequireSymbolAtEnd: true},
+		{Length: 8, UseDigits: true, UseLower: true, UseUpper: true, UseSymbols: true, RequireEachSet: &noEachSet, RequireSymbolAtEnd: true},
+		{Length: 8, UseLower: true, UseSymbols: true, RequireEachSet: &noEachSet, Weights: map[string]int{"lower": 1}, RequireSymbolAtEnd: true},
+	}
+
+	for _, config := range configs {
+		gen, err := NewGenerator(config)
+		if err != nil {
+			t.Fatalf("NewGenerator() failed: %v", err)
+		}
+
+		passwords, err := gen.GenerateUnique(300)
+		if err != nil {
+			t.Fatalf("GenerateUnique() failed for %+v: %v", config, err)
+		}
+		for _, password := range passwords {
+			if !strings.ContainsRune(symbols, rune(password[len(password)-1])) {
+				t.Errorf("Password %q does not end with a symbol", password)
+			}
+			if err := gen.Validate(password); err != nil {
+				t.Errorf("Validate(%q) = %v", password, err)
+			}
+		}
+	}
+}
+
+// Кандидат без спецсимвола не прерывает генерацию ошибкой: порядок символов
+// не меняется, и checkRules отправляет его на повторную генерацию
+func TestMoveSymbolToEndWithoutSymbol(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 8, UseLower: true, UseSymbols: true, RequireSymbolAtEnd: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	password := []rune("abcdefgh")
+	if err := gen.moveSymbolToEnd(password); err != nil {
+		t.Fatalf("moveSymbolToEnd() failed: %v", err)
+	}
+	if string(password) != "abcdefgh" {
+		t.Errorf("moveSymbolToEnd() changed password to %q", string(password))
+	}
+	if err := gen.checkRules(string(password)); err == nil {
+		t.Error("checkRules() accepted a password without a trailing symbol")
+	}
+}
+
+func TestRequireSymbolAtEndValidation(t *testing.T) {
+	if _, err := NewGenerator(Config{Length: 8, UseLower: true, RequireSymbolAtEnd: true}); err == nil {
+		t.Error("Expected error without symbols set, got none")
+	}
+
+	if _, err := NewGenerator(Config{Length: 8, UseLower: true, UseSymbols: true, ExcludeChars: symbols, RequireSymbolAtEnd: true}); err == nil {
+		t.Error("Expected error when all symbols are excluded, got none")
+	}
+
+	gen, err := NewGenerator(Config{Length: 4, UseLower: true, UseSymbols: true, RequireSymbolAtEnd: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+	if err := gen.Validate("a!bc"); err == nil {
+		t.Error("Validate() expected error for password without trailing symbol, got none")
+	}
+	if err := gen.Validate("abc!"); err != nil {
+		t.Errorf("Validate() = %v for password ending with a symbol", err)
+	}
+}
+
+func TestRuleViolationMessages(t *testing.T) {
+	tests := []struct {
+		name     string
+		lang     Lang
+		config   Config
+		password string
+		want     string
+	}{
+		{
+			name:     "клавиатурная серия",
+			lang:     Russian,
+			config:   Config{Length: 8, UseLower: true, AvoidKeyboardSequences: true},
+			password: "qwertxyz",
+			want:     "пароль нарушает правила: пароль содержит клавиатурную серию",
+		},
+		{
+			name:     "keyboard sequence",
+			lang:     English,
+			config:   Config{Length: 8, UseLower: true, AvoidKeyboardSequences: true},
+			password: "qwertxyz",
+			want:     "password violates the rules: password contains a keyboard sequence",
+		},
+		{
+			name:     "year",
+			lang:     English,
+			config:   Config{Length: 6, UseDigits: true, UseLower: true, AvoidYears: true},
+			password: "a1987b",
+			want:     "password violates the rules: password contains the year 1987",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useLanguage(t, tt.lang)
+
+			gen, err := NewGenerator(tt.config)
+			if err != nil {
+				t.Fatalf("NewGenerator() failed: %v", err)
+			}
+			err = gen.Validate(tt.password)
+			if err == nil || err.Error() != tt.want {
+				t.Fatalf("Validate(%q) error = %v, want %q", tt.password, err, tt.want)
+			}
+			if !errors.Is(err, ErrPolicyViolation) {
+				t.Errorf("errors.Is(%v, ErrPolicyViolation) = false", err)
+			}
+		})
+	}
+}
+
+func TestGenerateNoAdjacentRepeats(t *testing.T) {
+	// 3 символа и длина 12: повторы неизбежны, соседние - запрещены
+	gen, err := NewGenerator(Config{Length: 12, CustomChars: "abc", AllowRepeats: true, NoAdjacentRepeats: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	passwords, err := gen.GenerateUnique(200)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+
+	for _, password := range passwords {
+		for i := 1; i < len(password); i++ {
+			if password[i] == password[i-1] {
+				t.Errorf("Password %q contains adjacent repeat %q", password, password[i-1:i+1])
+				break
+			}
+		}
+		// Повторы в разных местах разрешены: хотя бы один символ встречается не раз
+		if strings.Count(password, string(password[0])) < 2 && strings.Count(password, string(password[1])) < 2 {
+			t.Errorf("Password %q has no non-adjacent repeats", password)
+		}
+	}
+}
+
+func TestNoAdjacentRepeatsValidate(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 5, UseLower: true, AllowRepeats: true, NoAdjacentRepeats: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	tests := []struct {
+		password string
+		wantErr  bool
+	}{
+		{password: "abcab", wantErr: false},
+		{password: "ababa", wantErr: false},
+		{password: "abbca", wantErr: true},
+		{password: "zzzzz", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		if err := gen.Validate(tt.password); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q) error = %v, wantErr %v", tt.password, err, tt.wantErr)
+		}
+	}
+}
+
+func TestFindYear(t *testing.T) {
+	tests := []struct {
+		password string
+		want     string
+		wantOK   bool
+	}{
+		{password: "ab1990cd", want: "1990", wantOK: true},
+		{password: "2023", want: "2023", wantOK: true},
+		{password: "x12099", want: "2099", wantOK: true},
+		{password: "1899x2100", wantOK: false},
+		{password: "19a90", wantOK: false},
+		{password: "abc", wantOK: false},
+		{password: "۱۹۹۰", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := findYear([]rune(tt.password))
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("findYear(%q) = %q, %v, want %q, %v", tt.password, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestGenerateAvoidYears(t *testing.T) {
+	// Только цифры с повторами: без правила годы встречаются часто
+	gen, err := NewGenerator(Config{Length: 12, UseDigits: true, AllowRepeats: true, AvoidYears: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	passwords, err := gen.GenerateUnique(500)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+
+	for _, password := range passwords {
+		if year, ok := findYear([]rune(password)); ok {
+			t.Errorf("Password %q contains year %s", password, year)
+		}
+		if err := gen.Validate(password); err != nil {
+			t.Errorf("Validate(%q) = %v", password, err)
+		}
+	}
+
+	if err := gen.Validate("123419873210"); err == nil {
+		t.Error("Validate() expected error for password with a year, got none")
+	}
+}
+
+func TestAvoidYearsWithoutDigits(t *testing.T) {
+	// Без набора цифр правило не действует, даже если цифры заданы в CustomChars
+	gen, err := NewGenerator(Config{Length: 4, CustomChars: "0129", AvoidYears: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+	if err := gen.Validate("2019"); err != nil {
+		t.Errorf("Validate(\"2019\") = %v, want nil without digits set", err)
+	}
+}
+
+func TestConfigAccept(t *testing.T) {
+	startsWithPrefixLetter := func(pwd string) bool { return strings.HasPrefix(pwd, "id-") && pwd[3] >= 'a' }
+	gen, err := NewGenerator(Config{Length: 4, UseDigits: true, UseLower: true, Prefix: "id-", Accept: startsWithPrefixLetter})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	passwords, err := gen.GenerateUnique(50)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+	many, err := gen.GenerateMany(50)
+	if err != nil {
+		t.Fatalf("GenerateMany() failed: %v", err)
+	}
+	for _, pwd := range append(passwords, many...) {
+		if !startsWithPrefixLetter(pwd) {
+			t.Errorf("Password %q was not re-rolled by Accept", pwd)
+		}
+	}
+
+	// Невыполнимое условие исчерпывает попытки
+	never, err := NewGenerator(Config{Length: 4, UseDigits: true, MaxAttempts: 50, Accept: func(string) bool { return false }})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+	if _, err := never.Generate(); !errors.Is(err, ErrCharsetExhausted) {
+		t.Errorf("Generate() error = %v, want ErrCharsetExhausted", err)
+	}
+}
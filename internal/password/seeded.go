@@ -0,0 +1,22 @@
+package password
+
+import (
+	mathrand "math/rand"
+)
+
+// NewSeededGenerator создаёт генератор с детерминированным источником
+// случайности на основе seed. Одинаковый seed даёт одинаковую
+// последовательность паролей.
+//
+// ВНИМАНИЕ: такой генератор НЕБЕЗОПАСЕН и предназначен только для тестов
+// и воспроизводимых тестовых векторов. Никогда не используйте его для
+// настоящих паролей - используйте NewGenerator.
+func NewSeededGenerator(config Config, seed int64) (*Generator, error) {
+	gen, err := NewGenerator(config)
+	if err != nil {
+		return nil, err
+	}
+
+	gen.rng = mathrand.New(mathrand.NewSource(seed))
+	return gen, nil
+}
@@ -0,0 +1,55 @@
+package password
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSeededGeneratorReproducible(t *testing.T) {
+	config := Config{
+		Length:    12,
+		UseDigits: true,
+		UseLower:  true,
+		UseUpper:  true,
+	}
+
+	first, err := NewSeededGenerator(config, 42)
+	if err != nil {
+		t.Fatalf("NewSeededGenerator() failed: %v", err)
+	}
+	second, err := NewSeededGenerator(config, 42)
+	if err != nil {
+		t.Fatalf("NewSeededGenerator() failed: %v", err)
+	}
+	other, err := NewSeededGenerator(config, 43)
+	if err != nil {
+		t.Fatalf("NewSeededGenerator() failed: %v", err)
+	}
+
+	firstPasswords, err := first.GenerateUnique(20)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+	secondPasswords, err := second.GenerateUnique(20)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+	otherPasswords, err := other.GenerateUnique(20)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(firstPasswords, secondPasswords) {
+		t.Errorf("Same seed produced different sequences:\n%v\n%v", firstPasswords, secondPasswords)
+	}
+
+	if reflect.DeepEqual(firstPasswords, otherPasswords) {
+		t.Errorf("Different seeds produced identical sequences: %v", firstPasswords)
+	}
+}
+
+func TestSeededGeneratorInvalidConfig(t *testing.T) {
+	if _, err := NewSeededGenerator(Config{Length: 11, UseDigits: true}, 1); err == nil {
+		t.Error("Expected error for invalid config, got none")
+	}
+}
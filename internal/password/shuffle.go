@@ -0,0 +1,7 @@
+package password
+
+// ShuffleStrings перемешивает пароли на месте криптографически стойким
+// Fisher-Yates, чтобы позиция в пачке не выдавала порядок генерации
+func ShuffleStrings(items []string) error {
+	return shuffle(items, secureRandomInt)
+}
@@ -0,0 +1,49 @@
+package password
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestShuffleStrings(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 12, UseDigits: true, UseLower: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+	original, err := gen.GenerateUnique(50)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+
+	orders := make(map[string]bool)
+	for run := 0; run < 5; run++ {
+		shuffled := slices.Clone(original)
+		if err := ShuffleStrings(shuffled); err != nil {
+			t.Fatalf("ShuffleStrings() failed: %v", err)
+		}
+
+		// Набор паролей не меняется, меняется только порядок
+		sortedOriginal := slices.Sorted(slices.Values(original))
+		if got := slices.Sorted(slices.Values(shuffled)); !slices.Equal(got, sortedOriginal) {
+			t.Fatalf("ShuffleStrings() changed the multiset of passwords")
+		}
+		orders[shuffled[0]+shuffled[1]+shuffled[2]] = true
+	}
+
+	// Первые три пароля совпадают во всех пяти запусках с ничтожной вероятностью
+	if len(orders) < 2 {
+		t.Errorf("ShuffleStrings() produced the same order in all runs")
+	}
+}
+
+func TestShuffleStringsEdgeCases(t *testing.T) {
+	for _, items := range [][]string{nil, {}, {"a"}} {
+		before := slices.Clone(items)
+		if err := ShuffleStrings(items); err != nil {
+			t.Errorf("ShuffleStrings(%q) failed: %v", items, err)
+		}
+		if !slices.Equal(items, before) {
+			t.Errorf("ShuffleStrings(%q) changed a trivial slice", before)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package password
+
+import (
+	"context"
+	"math"
+	"math/big"
+)
+
+// Stats содержит сводку по сгенерированной пачке паролей
+type Stats struct {
+	Attempts    int     // число попыток генерации, включая отброшенные кандидаты
+	MinEntropy  float64 // минимальная энтропия пароля в пачке, бит
+	MaxEntropy  float64 // максимальная энтропия пароля в пачке, бит
+	MeanEntropy float64 // средняя энтропия паролей в пачке, бит
+	Warning     string  // предупреждение о приближении к исчерпанию комбинаций
+}
+
+// exhaustionThreshold - доля использованных комбинаций, после которой выдаётся предупреждение
+const exhaustionThreshold = 0.5
+
+// GenerateUniqueWithStats генерирует count уникальных паролей, как GenerateUnique,
+// и дополнительно возвращает статистику по пачке
+func (g *Generator) GenerateUniqueWithStats(count int) ([]string, Stats, error) {
+	return g.GenerateUniqueWithStatsContext(context.Background(), count)
+}
+
+// GenerateUniqueWithStatsContext - вариант GenerateUniqueWithStats, который
+// прерывается при отмене ctx, как GenerateUniqueContext
+func (g *Generator) GenerateUniqueWithStatsContext(ctx context.Context, count int) ([]string, Stats, error) {
+	startAttempts := g.attempts
+
+	passwords, err := g.GenerateUniqueContext(ctx, count)
+	stats := Stats{Attempts: g.attempts - startAttempts}
+	if err != nil {
+		return nil, stats, err
+	}
+
+	stats.MinEntropy = math.Inf(1)
+	total := 0.0
+	for _, pwd := range passwords {
+		bits := g.PasswordEntropy(pwd)
+		stats.MinEntropy = min(stats.MinEntropy, bits)
+		stats.MaxEntropy = max(stats.MaxEntropy, bits)
+		total += bits
+	}
+	stats.MeanEntropy = total / float64(len(passwords))
+
+	// Предупреждаем, если использована значительная часть возможных комбинаций
+	used := new(big.Float).SetInt64(int64(len(g.used)))
+	limit := new(big.Float).Mul(new(big.Float).SetInt(g.MaxUnique()), big.NewFloat(exhaustionThreshold))
+	if used.Cmp(limit) >= 0 {
+		stats.Warning = msg(msgStatsExhaustionWarning)
+	}
+
+	return passwords, stats, nil
+}
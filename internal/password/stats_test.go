@@ -0,0 +1,72 @@
+package password
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGenerateUniqueWithStats(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 12, UseDigits: true, UseLower: true, UseUpper: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	passwords, stats, err := gen.GenerateUniqueWithStats(20)
+	if err != nil {
+		t.Fatalf("GenerateUniqueWithStats() failed: %v", err)
+	}
+
+	if len(passwords) != 20 {
+		t.Errorf("got %d passwords, want 20", len(passwords))
+	}
+	if stats.Attempts < 20 {
+		t.Errorf("Attempts = %d, want at least 20", stats.Attempts)
+	}
+
+	want := entropyBits(62, 12, false)
+	if math.Abs(stats.MinEntropy-want) > 1e-9 || math.Abs(stats.MaxEntropy-want) > 1e-9 || math.Abs(stats.MeanEntropy-want) > 1e-9 {
+		t.Errorf("entropy stats = %+v, want all %f", stats, want)
+	}
+	if stats.Warning != "" {
+		t.Errorf("Warning = %q, want empty for large space", stats.Warning)
+	}
+}
+
+func TestGenerateUniqueWithStatsCollisions(t *testing.T) {
+	// digits длины 2: всего 90 комбинаций, при 80 паролях коллизии неизбежны
+	gen, err := NewGenerator(Config{Length: 2, UseDigits: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	_, stats, err := gen.GenerateUniqueWithStats(80)
+	if err != nil {
+		t.Fatalf("GenerateUniqueWithStats() failed: %v", err)
+	}
+
+	if stats.Attempts <= 80 {
+		t.Errorf("Attempts = %d, want more than 80 due to collisions", stats.Attempts)
+	}
+	if stats.Warning == "" {
+		t.Error("Expected near-exhaustion warning, got none")
+	}
+}
+
+func TestGenerateUniqueWithStatsRange(t *testing.T) {
+	gen, err := NewGenerator(Config{MinLength: 4, MaxLength: 12, UseLower: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	_, stats, err := gen.GenerateUniqueWithStats(100)
+	if err != nil {
+		t.Fatalf("GenerateUniqueWithStats() failed: %v", err)
+	}
+
+	if stats.MinEntropy > stats.MeanEntropy || stats.MeanEntropy > stats.MaxEntropy {
+		t.Errorf("entropy stats out of order: %+v", stats)
+	}
+	if stats.MinEntropy == stats.MaxEntropy {
+		t.Errorf("Expected entropy to vary with length, got %+v", stats)
+	}
+}
@@ -0,0 +1,74 @@
+package password
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// UsedStore хранит пароли, выданные ранее, чтобы генератор не повторял их
+// между разными запусками
+type UsedStore interface {
+	Has(password string) bool
+	Add(password string) error
+}
+
+// FileStore - хранилище использованных паролей в текстовом файле,
+// по одному паролю на строку. Файл создаётся с правами 0600.
+type FileStore struct {
+	mu   sync.Mutex
+	file *os.File
+	used map[string]struct{}
+}
+
+// NewFileStore открывает (или создаёт) файл хранилища и загружает
+// из него ранее выданные пароли
+func NewFileStore(path string) (*FileStore, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf(msg(msgStoreOpenFailed), err)
+	}
+
+	used := make(map[string]struct{})
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line != "" {
+			used[line] = struct{}{}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		file.Close()
+		return nil, fmt.Errorf(msg(msgStoreReadFailed), err)
+	}
+
+	return &FileStore{file: file, used: used}, nil
+}
+
+// Has проверяет, выдавался ли пароль ранее
+func (s *FileStore) Has(password string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, exists := s.used[password]
+	return exists
+}
+
+// Add запоминает пароль и дописывает его в файл
+func (s *FileStore) Add(password string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := fmt.Fprintln(s.file, password); err != nil {
+		return fmt.Errorf(msg(msgStoreWriteFailed), err)
+	}
+	s.used[password] = struct{}{}
+	return nil
+}
+
+// Close закрывает файл хранилища
+func (s *FileStore) Close() error {
+	return s.file.Close()
+}
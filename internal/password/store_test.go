@@ -0,0 +1,97 @@
+package password
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileStoreAcrossRuns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "used.txt")
+	config := Config{Length: 3, UseDigits: true}
+
+	// Первый запуск
+	store, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() failed: %v", err)
+	}
+	config.Store = store
+
+	gen, err := NewGenerator(config)
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+	first, err := gen.GenerateUnique(100)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	// Второй запуск с тем же файлом
+	store, err = NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() failed: %v", err)
+	}
+	defer store.Close()
+
+	for _, pwd := range first {
+		if !store.Has(pwd) {
+			t.Errorf("Password %q from first run is not in store", pwd)
+		}
+	}
+
+	config.Store = store
+	gen, err = NewGenerator(config)
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+	second, err := gen.GenerateUnique(100)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	for _, pwd := range first {
+		seen[pwd] = true
+	}
+	for _, pwd := range second {
+		if seen[pwd] {
+			t.Errorf("Password %q repeated across runs", pwd)
+		}
+	}
+}
+
+func TestFileStorePermissionsAndFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "used.txt")
+
+	store, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() failed: %v", err)
+	}
+	if err := store.Add("abc"); err != nil {
+		t.Fatalf("Add() failed: %v", err)
+	}
+	if err := store.Add("xyz"); err != nil {
+		t.Fatalf("Add() failed: %v", err)
+	}
+	store.Close()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat() failed: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("file mode = %o, want 600", perm)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() failed: %v", err)
+	}
+	if got := strings.Split(strings.TrimSpace(string(data)), "\n"); len(got) != 2 || got[0] != "abc" || got[1] != "xyz" {
+		t.Errorf("store contents = %q, want abc and xyz lines", data)
+	}
+}
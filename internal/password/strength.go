@@ -0,0 +1,41 @@
+package password
+
+// strengthLabels содержит текстовые метки для оценок 0-4
+var strengthLabels = [...]string{"very weak", "weak", "fair", "strong", "very strong"}
+
+// Strength оценивает надёжность пароля по шкале 0-4 и возвращает оценку
+// вместе с текстовой меткой. Учитываются длина, разнообразие наборов символов,
+// а также серии повторов и последовательностей.
+func Strength(password string) (int, string) {
+	runes := []rune(password)
+	score := 0
+
+	// Длина
+	switch {
+	case len(runes) >= 16:
+		score += 3
+	case len(runes) >= 12:
+		score += 2
+	case len(runes) >= 8:
+		score++
+	}
+
+	// Разнообразие наборов символов
+	switch classes := Analyze(password).Classes(); {
+	case classes >= 4:
+		score += 2
+	case classes == 3:
+		score++
+	}
+
+	// Штрафы за повторы и последовательности
+	if longestRepeatRun(runes) >= 3 {
+		score--
+	}
+	if longestSequentialRun(runes) >= 3 {
+		score--
+	}
+
+	score = min(max(score, 0), 4)
+	return score, strengthLabels[score]
+}
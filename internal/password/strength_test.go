@@ -0,0 +1,29 @@
+package password
+
+import "testing"
+
+func TestStrength(t *testing.T) {
+	tests := []struct {
+		name      string
+		password  string
+		wantScore int
+		wantLabel string
+	}{
+		{name: "пустой пароль", password: "", wantScore: 0, wantLabel: "very weak"},
+		{name: "короткая последовательность", password: "abc123", wantScore: 0, wantLabel: "very weak"},
+		{name: "одно слово", password: "password", wantScore: 1, wantLabel: "weak"},
+		{name: "три набора, 8 символов", password: "Pa5sw0rd", wantScore: 2, wantLabel: "fair"},
+		{name: "три набора, 12 символов", password: "7mKqR1nZ4wL9", wantScore: 3, wantLabel: "strong"},
+		{name: "четыре набора, 16 символов", password: "Xk9#mPq2vL!tR4&z", wantScore: 4, wantLabel: "very strong"},
+		{name: "длинный с повторами", password: "aaaaaaaaaaaaaaaa", wantScore: 2, wantLabel: "fair"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, label := Strength(tt.password)
+			if score != tt.wantScore || label != tt.wantLabel {
+				t.Errorf("Strength(%q) = (%d, %q), want (%d, %q)", tt.password, score, label, tt.wantScore, tt.wantLabel)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package password
+
+// Validate проверяет пароль, выбранный пользователем, по той же политике,
+// что и генерация: длина, допустимые и исключённые символы, наличие символа
+// из каждого набора, запрет повторов и дополнительные правила. Возвращает
+// ошибку с описанием первого нарушения и причиной ErrPolicyViolation или nil.
+// Уникальность не проверяется.
+// Префикс и суффикс из конфигурации обязательны, политика применяется к
+// случайной части между ними.
+func (g *Generator) Validate(password string) error {
+	_, err := g.ValidateDetailed(password)
+	return err
+}
+
+// Violation описывает первое нарушение политики, найденное ValidateDetailed
+type Violation struct {
+	// Index - позиция нарушения в рунах от начала пароля (вместе с
+	// префиксом): недопустимый или лишний символ, место нехватающего
+	// символа. -1 - нарушение относится к паролю целиком, например
+	// нет символа из обязательного набора
+	Index int
+	// Char - символ в позиции Index, 0 - если символа нет
+	Char rune
+	// Reason - описание нарушения, совпадает с текстом ошибки
+	Reason string
+}
+
+// violation возвращает описание нарушения вместе с ошибкой err
+func violation(index int, char rune, err error) (*Violation, error) {
+	return &Violation{Index: index, Char: char, Reason: err.Error()}, err
+}
+
+// ValidateDetailed проверяет пароль, как Validate, и кроме ошибки
+// возвращает структурированное описание первого нарушения - например,
+// чтобы подсветить неподходящий символ в форме. Для подходящего пароля
+// возвращает nil, nil.
+func (g *Generator) ValidateDetailed(password string) (*Violation, error) {
+	runes := []rune(password)
+	prefix, suffix := []rune(g.prefix), []rune(g.suffix)
+
+	for i, r := range prefix {
+		if i >= len(runes) || runes[i] != r {
+			return violation(i, runeAt(runes, i), errorf(ErrPolicyViolation, msg(msgPrefixMissing), g.prefix))
+		}
+	}
+	if len(runes)-len(prefix) < len(suffix) || string(runes[len(runes)-len(suffix):]) != g.suffix {
+		return violation(-1, 0, errorf(ErrPolicyViolation, msg(msgSuffixMissing), g.suffix))
+	}
+	runes = runes[len(prefix) : len(runes)-len(suffix)]
+	offset := len(prefix)
+
+	length := len(runes)
+	minLength, maxLength := g.minLength, g.maxLength
+	if g.length > 0 {
+		minLength, maxLength = g.length, g.length
+	}
+	if length < minLength || length > maxLength {
+		// Индекс указывает на первый лишний символ или место первого нехватающего
+		index := offset + min(length, maxLength)
+		if g.length > 0 {
+			return violation(index, runeAt(runes, index-offset), errorf(ErrPolicyViolation, msg(msgLengthMismatch), length, g.length))
+		}
+		return violation(index, runeAt(runes, index-offset), errorf(ErrPolicyViolation, msg(msgLengthOutOfRange), length, g.minLength, g.maxLength))
+	}
+
+	for i, r := range runes {
+		if !containsRune(g.charset, r) {
+			return violation(offset+i, r, errorf(ErrPolicyViolation, msg(msgCharNotInCharset), r, offset+i))
+		}
+		if !g.allowRepeats && containsRune(runes[:i], r) {
+			return violation(offset+i, r, errorf(ErrPolicyViolation, msg(msgCharRepeated), r, offset+i))
+		}
+		if g.maxOccur > 0 && countRunes(runes[:i+1], []rune{r}) > g.maxOccur {
+			return violation(offset+i, r, errorf(ErrPolicyViolation, msg(msgCharTooFrequent), r, offset+i, g.maxOccur))
+		}
+	}
+
+	// Как и при генерации, символ из каждого набора требуется только при
+	// нескольких наборах и RequireEachSet, а с MinClasses - из MinClasses наборов
+	if g.minClasses > 0 {
+		if classes := countGroups(runes, g.charsets); classes < g.minClasses {
+			return violation(-1, 0, errorf(ErrPolicyViolation, msg(msgTooFewClasses), classes, g.minClasses))
+		}
+	} else if g.requireEachSet && len(g.charsets) > 1 {
+		for _, group := range g.charsets {
+			if !containsAnyRune(runes, group) {
+				return violation(-1, 0, errorf(ErrPolicyViolation, msg(msgMissingSet), string(group)))
+			}
+		}
+	}
+
+	for i, group := range g.charsets {
+		if g.groupMins == nil || g.groupMins[i] == 0 {
+			continue
+		}
+		if count := countRunes(runes, group); count < g.groupMins[i] {
+			return violation(-1, 0, errorf(ErrPolicyViolation, msg(msgGroupBelowMin), count, string(group), g.groupMins[i]))
+		}
+	}
+
+	if err := g.checkRules(string(runes)); err != nil {
+		return violation(-1, 0, errorf(ErrPolicyViolation, msg(msgRulesViolated), err))
+	}
+
+	return nil, nil
+}
+
+// runeAt возвращает символ с индексом i или 0, если его нет
+func runeAt(runes []rune, i int) rune {
+	if i < 0 || i >= len(runes) {
+		return 0
+	}
+	return runes[i]
+}
+
+// containsAnyRune проверяет, содержит ли срез хотя бы одну руну из group
+func containsAnyRune(slice []rune, group []rune) bool {
+	for _, r := range group {
+		if containsRune(slice, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// countGroups возвращает число наборов, символы которых встречаются в срезе
+func countGroups(slice []rune, groups [][]rune) int {
+	count := 0
+	for _, group := range groups {
+		if containsAnyRune(slice, group) {
+			count++
+		}
+	}
+	return count
+}
+
+// countRunes возвращает число символов среза, входящих в group
+func countRunes(slice []rune, group []rune) int {
+	count := 0
+	for _, r := range slice {
+		if containsRune(group, r) {
+			count++
+		}
+	}
+	return count
+}
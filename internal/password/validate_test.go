@@ -0,0 +1,196 @@
+package password
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	gen, err := NewGenerator(Config{
+		Length:         8,
+		UseDigits:      true,
+		UseLower:       true,
+		UseUpper:       true,
+		ExcludeChars:   "0O",
+		NoLeadingDigit: true,
+		Blocklist:      []string{"Abcdef12"},
+	})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		wantErr  bool
+	}{
+		{name: "корректный пароль", password: "aB3xY7kQ", wantErr: false},
+		{name: "слишком короткий", password: "aB3xY7k", wantErr: true},
+		{name: "слишком длинный", password: "aB3xY7kQz", wantErr: true},
+		{name: "исключённый символ", password: "aB3xY7kO", wantErr: true},
+		{name: "символ вне набора", password: "aB3xY7k!", wantErr: true},
+		{name: "повтор символа", password: "aB3xY7ka", wantErr: true},
+		{name: "нет цифры", password: "aBcxYzkQ", wantErr: true},
+		{name: "нет прописной", password: "ab3xy7kq", wantErr: true},
+		{name: "начинается с цифры", password: "3aBxY7kQ", wantErr: true},
+		{name: "запрещённый пароль", password: "Abcdef12", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := gen.Validate(tt.password)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate(%q) error = %v, wantErr %v", tt.password, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrPolicyViolation) {
+				t.Errorf("errors.Is(%v, ErrPolicyViolation) = false", err)
+			}
+		})
+	}
+}
+
+func TestValidateLengthRangeAndRepeats(t *testing.T) {
+	gen, err := NewGenerator(Config{MinLength: 4, MaxLength: 6, UseDigits: true, AllowRepeats: true, MaxConsecutive: 2})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	tests := []struct {
+		password string
+		wantErr  bool
+	}{
+		{password: "1122", wantErr: false},
+		{password: "112233", wantErr: false},
+		{password: "112", wantErr: true},
+		{password: "1122334", wantErr: true},
+		{password: "1112", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		err := gen.Validate(tt.password)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q) error = %v, wantErr %v", tt.password, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidateGeneratedPasswords(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 12, UseDigits: true, UseLower: true, UseUpper: true, UseSymbols: true, MaxSequential: 2})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	passwords, err := gen.GenerateUnique(100)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+
+	for _, password := range passwords {
+		if err := gen.Validate(password); err != nil {
+			t.Errorf("Validate(%q) = %v for generated password", password, err)
+		}
+	}
+}
+
+func TestValidateMinClasses(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 6, UseDigits: true, UseLower: true, UseUpper: true, UseSymbols: true, MinClasses: 3})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	if err := gen.Validate("abc12!"); err != nil {
+		t.Errorf("Validate() = %v for password with 3 classes", err)
+	}
+	if err := gen.Validate("abc123"); err == nil {
+		t.Error("Validate() expected error for password with 2 classes, got none")
+	}
+}
+
+func TestValidateDetailed(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 8, UseDigits: true, UseLower: true, UseUpper: true, ExcludeChars: "0O"})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+	withPrefix, err := NewGenerator(Config{Length: 4, UseLower: true, Prefix: "id-"})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		gen       *Generator
+		password  string
+		wantIndex int
+		wantChar  rune
+	}{
+		{name: "исключённый символ", gen: gen, password: "aB3xOy7k", wantIndex: 4, wantChar: 'O'},
+		{name: "символ вне набора", gen: gen, password: "aB3x@y7k", wantIndex: 4, wantChar: '@'},
+		{name: "повтор символа", gen: gen, password: "aB3xY7ka", wantIndex: 7, wantChar: 'a'},
+		{name: "слишком короткий", gen: gen, password: "aB3xY7k", wantIndex: 7, wantChar: 0},
+		{name: "слишком длинный", gen: gen, password: "aB3xY7kQz", wantIndex: 8, wantChar: 'z'},
+		{name: "нет цифры", gen: gen, password: "aBcxYzkQ", wantIndex: -1, wantChar: 0},
+		{name: "индекс с учётом префикса", gen: withPrefix, password: "id-ab1d", wantIndex: 5, wantChar: '1'},
+		{name: "не тот префикс", gen: withPrefix, password: "ix-abcd", wantIndex: 1, wantChar: 'x'},
+		{name: "длина с префиксом", gen: withPrefix, password: "id-abcde", wantIndex: 7, wantChar: 'e'},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := tt.gen.ValidateDetailed(tt.password)
+			if err == nil || v == nil {
+				t.Fatalf("ValidateDetailed(%q) = %v, %v, want violation", tt.password, v, err)
+			}
+			if v.Index != tt.wantIndex || v.Char != tt.wantChar {
+				t.Errorf("violation at %d (%q), want %d (%q)", v.Index, v.Char, tt.wantIndex, tt.wantChar)
+			}
+			if v.Reason != err.Error() {
+				t.Errorf("Reason = %q, want %q", v.Reason, err.Error())
+			}
+		})
+	}
+}
+
+func TestValidateDetailedValid(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 8, UseDigits: true, UseLower: true, UseUpper: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	if v, err := gen.ValidateDetailed("aB3xY7kQ"); v != nil || err != nil {
+		t.Errorf("ValidateDetailed() = %v, %v, want nil, nil", v, err)
+	}
+}
+
+func TestValidateDetailedMessage(t *testing.T) {
+	gen, err := NewGenerator(Config{Length: 8, UseDigits: true, UseLower: true, UseUpper: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	err = gen.Validate("aB3x@y7k")
+	if err == nil || !strings.Contains(err.Error(), `'@' с индексом 4`) {
+		t.Errorf("Validate() error = %v, want mention of '@' at index 4", err)
+	}
+}
+
+func TestValidateDetailedEnglish(t *testing.T) {
+	useLanguage(t, English)
+
+	gen, err := NewGenerator(Config{Length: 8, UseDigits: true, UseLower: true, UseUpper: true})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	v, err := gen.ValidateDetailed("aB3x@y7k")
+	want := "character '@' at index 4 is not in the allowed charset"
+	if err == nil || err.Error() != want {
+		t.Fatalf("ValidateDetailed() error = %v, want %q", err, want)
+	}
+	if v.Reason != want {
+		t.Errorf("Reason = %q, want %q", v.Reason, want)
+	}
+	if !errors.Is(err, ErrPolicyViolation) {
+		t.Errorf("errors.Is(%v, ErrPolicyViolation) = false", err)
+	}
+}
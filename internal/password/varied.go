@@ -0,0 +1,39 @@
+package password
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// GenerateUniqueVaried генерирует count уникальных паролей, как GenerateUnique,
+// и старается, чтобы соседние пароли пачки не начинались с одного символа:
+// при выводе столбцом такие пароли легче различать. Правило не строгое -
+// если за половину лимита попыток подходящий кандидат не найден, принимается
+// любой уникальный пароль.
+func (g *Generator) GenerateUniqueVaried(count int) ([]string, error) {
+	if err := g.checkUniqueCount(count); err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		var avoid func(string) bool
+		if i > 0 {
+			// Префикс у всех паролей общий, сравниваются первые случайные символы
+			previous, _ := utf8.DecodeRuneInString(result[i-1][len(g.prefix):])
+			avoid = func(candidate string) bool {
+				first, _ := utf8.DecodeRuneInString(candidate[len(g.prefix):])
+				return first == previous
+			}
+		}
+
+		password, err := g.generateAvoiding(avoid, g.maxAttempts/2)
+		if err != nil {
+			return nil, fmt.Errorf(msg(msgGenerateUniqueFailed), count, err)
+		}
+		result = append(result, password)
+		g.reportProgress(len(result), count)
+	}
+
+	return result, nil
+}
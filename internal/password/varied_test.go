@@ -0,0 +1,74 @@
+package password
+
+import "testing"
+
+// adjacentFirstCharCollisions считает соседние пароли с одинаковым первым символом
+func adjacentFirstCharCollisions(passwords []string) int {
+	collisions := 0
+	for i := 1; i < len(passwords); i++ {
+		if passwords[i][0] == passwords[i-1][0] {
+			collisions++
+		}
+	}
+	return collisions
+}
+
+func TestGenerateUniqueVaried(t *testing.T) {
+	// Три возможных первых символа: без эвристики совпадает примерно треть соседей
+	config := Config{Length: 4, CustomChars: "abc", AllowRepeats: true}
+
+	plain, err := NewSeededGenerator(config, 7)
+	if err != nil {
+		t.Fatalf("NewSeededGenerator() failed: %v", err)
+	}
+	varied, err := NewSeededGenerator(config, 7)
+	if err != nil {
+		t.Fatalf("NewSeededGenerator() failed: %v", err)
+	}
+
+	plainPasswords, err := plain.GenerateUnique(40)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+	variedPasswords, err := varied.GenerateUniqueVaried(40)
+	if err != nil {
+		t.Fatalf("GenerateUniqueVaried() failed: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	for _, password := range variedPasswords {
+		if seen[password] {
+			t.Errorf("Duplicate password %q", password)
+		}
+		seen[password] = true
+	}
+
+	plainCollisions := adjacentFirstCharCollisions(plainPasswords)
+	variedCollisions := adjacentFirstCharCollisions(variedPasswords)
+	if variedCollisions >= plainCollisions {
+		t.Errorf("varied collisions = %d, plain collisions = %d, want fewer with variation", variedCollisions, plainCollisions)
+	}
+	if variedCollisions != 0 {
+		t.Errorf("varied collisions = %d, want 0 when alternatives exist", variedCollisions)
+	}
+}
+
+func TestGenerateUniqueVariedBestEffort(t *testing.T) {
+	// Запрошены все 8 комбинаций: к концу пачки совпадений не избежать, но она всё равно генерируется
+	gen, err := NewGenerator(Config{Length: 3, CustomChars: "ab", AllowRepeats: true, MaxAttempts: 100})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	passwords, err := gen.GenerateUniqueVaried(8)
+	if err != nil {
+		t.Fatalf("GenerateUniqueVaried() failed: %v", err)
+	}
+	if len(passwords) != 8 {
+		t.Errorf("got %d passwords, want 8", len(passwords))
+	}
+
+	if _, err := gen.GenerateUniqueVaried(0); err == nil {
+		t.Error("Expected error for zero count, got none")
+	}
+}
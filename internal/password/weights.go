@@ -0,0 +1,108 @@
+package password
+
+// groupWeights сопоставляет веса из конфигурации группам charsets.
+// Возвращает nil, если веса не заданы (равномерный выбор).
+func groupWeights(config map[string]int, charsets [][]rune, names []string) ([]int, error) {
+	if len(config) == 0 {
+		return nil, nil
+	}
+
+	weights := make([]int, len(charsets))
+	total := 0
+	for i, name := range names {
+		weights[i] = config[name]
+		total += weights[i]
+	}
+
+	if total == 0 {
+		return nil, errorf(ErrInvalidConfig, msg(msgNoPositiveWeight))
+	}
+
+	return weights, nil
+}
+
+// weightedCapacity возвращает наибольшую длину пароля без повторов при
+// заданных весах: наборы с нулевым весом дают только обязательный символ
+func weightedCapacity(charsets [][]rune, weights []int, required bool) int {
+	capacity := 0
+	for i, group := range charsets {
+		switch {
+		case weights == nil || weights[i] > 0:
+			capacity += len(group)
+		case required && len(charsets) > 1:
+			capacity++
+		}
+	}
+	return capacity
+}
+
+// canUse сообщает, можно ли ещё раз добавить r к result: без повторов -
+// только если r ещё не встречался, с пределом вхождений - пока предел не
+// достигнут
+func (g *Generator) canUse(result []rune, r rune) bool {
+	switch {
+	case !g.allowRepeats:
+		return !containsRune(result, r)
+	case g.maxOccur > 0:
+		return countRunes(result, []rune{r}) < g.maxOccur
+	}
+	return true
+}
+
+// fillWeighted дополняет result до length символов: сначала набор выбирается
+// пропорционально весу, затем символ внутри него. Без повторов уже
+// использованные символы из выбора исключаются.
+func (g *Generator) fillWeighted(result []rune, length int) ([]rune, error) {
+	available := make([][]rune, len(g.charsets))
+	for i, group := range g.charsets {
+		for _, r := range group {
+			if g.canUse(result, r) {
+				available[i] = append(available[i], r)
+			}
+		}
+	}
+
+	for len(result) < length {
+		total := 0
+		for i, group := range available {
+			if len(group) > 0 {
+				total += g.weights[i]
+			}
+		}
+		if total == 0 {
+			return nil, errorf(ErrCharsetExhausted, msg(msgNotEnoughUnique))
+		}
+
+		pick, err := g.randomInt(total)
+		if err != nil {
+			return nil, err
+		}
+
+		groupIdx := 0
+		for i, group := range available {
+			if len(group) == 0 {
+				continue
+			}
+			if pick < g.weights[i] {
+				groupIdx = i
+				break
+			}
+			pick -= g.weights[i]
+		}
+
+		group := available[groupIdx]
+		charIdx, err := g.randomInt(len(group))
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, group[charIdx])
+		if !g.canUse(result, group[charIdx]) {
+			last := len(group) - 1
+			group[charIdx] = group[last]
+			available[groupIdx] = group[:last]
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,108 @@
+package password
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestGenerateWeightsDistribution(t *testing.T) {
+	// Обязательные символы дают по одной цифре и одной букве, остальные 38
+	// позиций заполняются с весами 8:1
+	const (
+		length = 40
+		count  = 500
+	)
+	gen, err := NewSeededGenerator(Config{
+		Length:       length,
+		UseDigits:    true,
+		UseLower:     true,
+		AllowRepeats: true,
+		Weights:      map[string]int{"lower": 8, "digits": 1},
+	}, 42)
+	if err != nil {
+		t.Fatalf("NewSeededGenerator() failed: %v", err)
+	}
+
+	passwords, err := gen.GenerateMany(count)
+	if err != nil {
+		t.Fatalf("GenerateMany() failed: %v", err)
+	}
+
+	digitCount := 0
+	for _, password := range passwords {
+		digitCount += Analyze(password).Digits
+	}
+
+	got := float64(digitCount) / float64(count*length)
+	want := (1 + float64(length-2)/9) / length
+	if math.Abs(got-want) > 0.01 {
+		t.Errorf("digit share = %.4f, want %.4f ± 0.01", got, want)
+	}
+}
+
+func TestGenerateWeightsZeroWeight(t *testing.T) {
+	// Набор с нулевым весом попадает в пароль только обязательным символом
+	gen, err := NewGenerator(Config{
+		Length:    20,
+		UseDigits: true,
+		UseLower:  true,
+		UseUpper:  true,
+		Weights:   map[string]int{"lower": 1, "upper": 1},
+	})
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+
+	passwords, err := gen.GenerateUnique(100)
+	if err != nil {
+		t.Fatalf("GenerateUnique() failed: %v", err)
+	}
+
+	for _, password := range passwords {
+		c := Analyze(password)
+		if c.Digits != 1 {
+			t.Errorf("Password %q has %d digits, want 1", password, c.Digits)
+		}
+		if c.Lower == 0 || c.Upper == 0 {
+			t.Errorf("Password %q misses a required set", password)
+		}
+		for _, r := range password {
+			if strings.Count(password, string(r)) > 1 {
+				t.Errorf("Password %q has repeated character %q", password, r)
+			}
+		}
+	}
+}
+
+func TestWeightsValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{
+			name:   "неизвестный набор",
+			config: Config{Length: 8, UseLower: true, Weights: map[string]int{"letters": 1}},
+		},
+		{
+			name:   "отрицательный вес",
+			config: Config{Length: 8, UseLower: true, UseDigits: true, Weights: map[string]int{"digits": -1, "lower": 2}},
+		},
+		{
+			name:   "все веса нулевые",
+			config: Config{Length: 8, UseLower: true, UseDigits: true, Weights: map[string]int{"upper": 5}},
+		},
+		{
+			name:   "не хватает символов с учётом весов",
+			config: Config{Length: 12, UseDigits: true, UseSymbols: true, Weights: map[string]int{"digits": 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewGenerator(tt.config); err == nil {
+				t.Error("NewGenerator() expected error, got none")
+			}
+		})
+	}
+}
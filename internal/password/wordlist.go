@@ -0,0 +1,127 @@
+package password
+
+import (
+	"bufio"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+)
+
+// MinWordlistSize - минимальное число разных слов в словаре для парольных
+// фраз: не меньше 10 бит энтропии на слово, как у коротких списков diceware
+const MinWordlistSize = 1024
+
+// LoadWordlist читает словарь для парольных фраз: по слову на строку,
+// пустые строки и строки с # пропускаются, повторы слов удаляются. Строки
+// в формате diceware ("11111 abacus") тоже поддерживаются - номер броска
+// отбрасывается. Словарь меньше MinWordlistSize слов отклоняется с
+// ErrInvalidConfig.
+func LoadWordlist(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf(msg(msgWordlistOpenFailed), err)
+	}
+	defer file.Close()
+
+	var words []string
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(file)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 2 && isDigitsOnly(fields[0]) {
+			fields = fields[1:]
+		}
+		if len(fields) > 1 {
+			return nil, errorf(ErrInvalidConfig, msg(msgWordlistLineSpaces), path, lineNum)
+		}
+
+		if word := fields[0]; !seen[word] {
+			seen[word] = true
+			words = append(words, word)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf(msg(msgWordlistReadFailed), err)
+	}
+
+	if len(words) < MinWordlistSize {
+		return nil, errorf(ErrInvalidConfig, msg(msgWordlistTooSmall), path, len(words), MinWordlistSize)
+	}
+
+	return words, nil
+}
+
+// isDigitsOnly проверяет, что строка состоит только из цифр ASCII
+func isDigitsOnly(s string) bool {
+	return strings.Trim(s, digits) == ""
+}
+
+// GeneratePassphrases генерирует count разных парольных фраз из words
+// случайных слов словаря wordlist, соединённых separator. Слова выбираются
+// независимо через secureRandomInt и могут повторяться внутри фразы.
+func GeneratePassphrases(wordlist []string, words, count int, separator string) ([]string, error) {
+	return GeneratePassphrasesFiltered(wordlist, words, count, separator, nil)
+}
+
+// GeneratePassphrasesFiltered генерирует парольные фразы, как
+// GeneratePassphrases, отбрасывая фразы с подстроками из filter (в том числе
+// сложившимися на стыке слов). nil отключает фильтр.
+func GeneratePassphrasesFiltered(wordlist []string, words, count int, separator string, filter *ProfanityFilter) ([]string, error) {
+	if words <= 0 {
+		return nil, errorf(ErrInvalidConfig, msg(msgWordsNotPositive))
+	}
+	if len(wordlist) == 0 {
+		return nil, errorf(ErrInvalidConfig, msg(msgWordlistEmpty))
+	}
+	if count <= 0 {
+		return nil, errorf(ErrInvalidCount, msg(msgCountNotPositive))
+	}
+
+	maxUnique := new(big.Int).Exp(big.NewInt(int64(len(wordlist))), big.NewInt(int64(words)), nil)
+	if big.NewInt(int64(count)).Cmp(maxUnique) > 0 {
+		return nil, errorf(ErrCharsetExhausted, msg(msgCountExceedsMaxUnique), count, maxUnique)
+	}
+
+	result := make([]string, 0, count)
+	used := make(map[string]struct{}, count)
+	phrase := make([]string, words)
+	// blocked - сколько из подряд идущих промахов отброшено фильтром
+	for misses, blocked := 0, 0; len(result) < count; {
+		if misses >= defaultMaxAttempts {
+			if blocked == misses {
+				return nil, errorf(ErrCharsetExhausted, msg(msgProfanityAttemptsExhausted), defaultMaxAttempts)
+			}
+			return nil, errorf(ErrCharsetExhausted, msg(msgUniqueAttemptsExhausted), defaultMaxAttempts)
+		}
+
+		for i := range phrase {
+			idx, err := secureRandomInt(len(wordlist))
+			if err != nil {
+				return nil, err
+			}
+			phrase[i] = wordlist[idx]
+		}
+
+		candidate := strings.Join(phrase, separator)
+		if filter != nil && filter.Contains(candidate) {
+			misses++
+			blocked++
+			continue
+		}
+		if _, exists := used[candidate]; exists {
+			misses++
+			continue
+		}
+		used[candidate] = struct{}{}
+		result = append(result, candidate)
+		misses, blocked = 0, 0
+	}
+
+	return result, nil
+}
@@ -0,0 +1,199 @@
+package password
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeWordlist записывает содержимое словаря во временный файл
+func writeWordlist(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+	return path
+}
+
+// wordlistLines возвращает n разных слов по одному на строку
+func wordlistLines(n int) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "word%04d\n", i)
+	}
+	return b.String()
+}
+
+func TestLoadWordlist(t *testing.T) {
+	content := "# Словарь для тестов\n" +
+		"\n" +
+		"  alpha  \n" +
+		"11111\tbravo\n" +
+		"   # комментарий с отступом\n" +
+		"alpha\n" +
+		"charlie\r\n" +
+		"\n" +
+		wordlistLines(MinWordlistSize)
+
+	words, err := LoadWordlist(writeWordlist(t, content))
+	if err != nil {
+		t.Fatalf("LoadWordlist() failed: %v", err)
+	}
+
+	if len(words) != MinWordlistSize+3 {
+		t.Errorf("len(words) = %d, want %d", len(words), MinWordlistSize+3)
+	}
+	want := []string{"alpha", "bravo", "charlie", "word0000"}
+	for i, word := range want {
+		if words[i] != word {
+			t.Errorf("words[%d] = %q, want %q", i, words[i], word)
+		}
+	}
+	for _, word := range words {
+		if strings.HasPrefix(word, "#") || strings.TrimSpace(word) != word || word == "" {
+			t.Errorf("unexpected word %q", word)
+		}
+	}
+}
+
+func TestLoadWordlistErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		kind    error
+		wantErr string
+	}{
+		{
+			name:    "мало слов",
+			content: "# мало\n" + wordlistLines(10),
+			kind:    ErrInvalidConfig,
+			wantErr: "10 разных слов",
+		},
+		{
+			name:    "повторы не считаются",
+			content: wordlistLines(MinWordlistSize-1) + "word0000\n",
+			kind:    ErrInvalidConfig,
+			wantErr: "1023 разных слов",
+		},
+		{
+			name:    "пробел внутри слова",
+			content: "alpha\nice cream\n" + wordlistLines(MinWordlistSize),
+			kind:    ErrInvalidConfig,
+			wantErr: "строка 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadWordlist(writeWordlist(t, tt.content))
+			if !errors.Is(err, tt.kind) {
+				t.Fatalf("LoadWordlist() error = %v, want %v", err, tt.kind)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error %q should contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadWordlistMissingFile(t *testing.T) {
+	if _, err := LoadWordlist(filepath.Join(t.TempDir(), "missing.txt")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("LoadWordlist() error = %v, want os.ErrNotExist", err)
+	}
+}
+
+func TestGeneratePassphrases(t *testing.T) {
+	wordlist := strings.Fields(wordlistLines(MinWordlistSize))
+	known := make(map[string]bool, len(wordlist))
+	for _, word := range wordlist {
+		known[word] = true
+	}
+
+	phrases, err := GeneratePassphrases(wordlist, 5, 50, "-")
+	if err != nil {
+		t.Fatalf("GeneratePassphrases() failed: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	for _, phrase := range phrases {
+		if seen[phrase] {
+			t.Errorf("duplicate passphrase %q", phrase)
+		}
+		seen[phrase] = true
+
+		words := strings.Split(phrase, "-")
+		if len(words) != 5 {
+			t.Errorf("passphrase %q has %d words, want 5", phrase, len(words))
+		}
+		for _, word := range words {
+			if !known[word] {
+				t.Errorf("passphrase %q contains unknown word %q", phrase, word)
+			}
+		}
+	}
+}
+
+func TestGeneratePassphrasesErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		wordlist []string
+		words    int
+		count    int
+		kind     error
+	}{
+		{"нет слов", []string{"a", "b"}, 0, 1, ErrInvalidConfig},
+		{"пустой словарь", nil, 3, 1, ErrInvalidConfig},
+		{"неположительное количество", []string{"a", "b"}, 3, 0, ErrInvalidCount},
+		{"больше возможных фраз", []string{"a", "b"}, 2, 5, ErrCharsetExhausted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := GeneratePassphrases(tt.wordlist, tt.words, tt.count, " "); !errors.Is(err, tt.kind) {
+				t.Errorf("GeneratePassphrases() error = %v, want %v", err, tt.kind)
+			}
+		})
+	}
+}
+
+func TestGeneratePassphrasesAllCombinations(t *testing.T) {
+	phrases, err := GeneratePassphrases([]string{"a", "b"}, 2, 4, "")
+	if err != nil {
+		t.Fatalf("GeneratePassphrases() failed: %v", err)
+	}
+	seen := make(map[string]bool)
+	for _, phrase := range phrases {
+		seen[phrase] = true
+	}
+	if len(seen) != 4 {
+		t.Errorf("got %v, want all 4 combinations", phrases)
+	}
+}
+
+func TestGeneratePassphrasesFiltered(t *testing.T) {
+	wordlist := []string{"apple", "shit", "river", "stone"}
+	filter := NewProfanityFilter([]string{"shit"})
+
+	phrases, err := GeneratePassphrasesFiltered(wordlist, 2, 9, "-", filter)
+	if err != nil {
+		t.Fatalf("GeneratePassphrasesFiltered() failed: %v", err)
+	}
+	for _, phrase := range phrases {
+		if strings.Contains(phrase, "shit") {
+			t.Errorf("phrase %q contains a blocked word", phrase)
+		}
+	}
+
+	// Все фразы из одного слова запрещены: ошибка говорит о фильтре
+	_, err = GeneratePassphrasesFiltered([]string{"shit"}, 3, 1, "-", filter)
+	if want := fmt.Sprintf(msg(msgProfanityAttemptsExhausted), defaultMaxAttempts); err == nil || err.Error() != want {
+		t.Errorf("GeneratePassphrasesFiltered() error = %v, want %q", err, want)
+	}
+	if !errors.Is(err, ErrCharsetExhausted) {
+		t.Errorf("errors.Is(%v, ErrCharsetExhausted) = false", err)
+	}
+}